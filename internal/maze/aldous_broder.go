@@ -0,0 +1,48 @@
+package maze
+
+import "math/rand"
+
+// AldousBroderAlgorithm implements maze generation using the Aldous-Broder algorithm.
+// Like Wilson's algorithm it produces uniform spanning trees, which makes it a
+// useful independent cross-check, but it is slower because it relies on a
+// plain random walk that has to cover every cell.
+type AldousBroderAlgorithm struct{}
+
+// Generate implements the Algorithm interface using the Aldous-Broder algorithm
+func (a *AldousBroderAlgorithm) Generate(maze *Maze, startRow, startCol int, rng *rand.Rand) {
+	generateOnGrid(a, maze, startRow, startCol, rng)
+}
+
+// GenerateGraph implements the GraphAlgorithm interface. It walks randomly
+// from cell to cell and carves a passage whenever the walk enters a cell for
+// the first time.
+func (a *AldousBroderAlgorithm) GenerateGraph(graph Graph, start int, rng *rand.Rand) {
+	visited := make([]bool, graph.CellCount())
+	remaining := len(visited) - 1
+
+	visited[start] = true
+	current := start
+
+	var slots, targets []int
+	for remaining > 0 {
+		slots, targets = slots[:0], targets[:0]
+		for slot := 0; slot < graph.Degree(current); slot++ {
+			if next := graph.Neighbor(current, slot); next >= 0 {
+				slots = append(slots, slot)
+				targets = append(targets, next)
+			}
+		}
+
+		choice := rng.Intn(len(slots))
+		next := targets[choice]
+
+		// First visit: connect the new cell to the cell we came from
+		if !visited[next] {
+			visited[next] = true
+			graph.Link(current, slots[choice])
+			remaining--
+		}
+
+		current = next
+	}
+}
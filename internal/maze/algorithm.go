@@ -3,6 +3,7 @@ package maze
 import (
 	"fmt"
 	"math/rand"
+	"strings"
 )
 
 // Algorithm defines the interface for maze generation algorithms
@@ -11,21 +12,123 @@ type Algorithm interface {
 	Generate(maze *Maze, startRow, startCol int, rng *rand.Rand)
 }
 
+// RowGenerator is implemented by algorithms that can build a maze one grid row at
+// a time from top to bottom while keeping only a few rows in memory.
+type RowGenerator interface {
+	// GenerateRows emits every grid row of a width x height maze in order.
+	// Generation stops early if emit returns an error, which is then returned.
+	GenerateRows(width, height int, rng *rand.Rand, emit func(row []bool) error) error
+}
+
+// generateFromRows fills the maze grid with the rows produced by a RowGenerator
+func generateFromRows(rowGenerator RowGenerator, maze *Maze, rng *rand.Rand) {
+	i := 0
+	_ = rowGenerator.GenerateRows(maze.Width, maze.Height, rng, func(row []bool) error {
+		maze.Grid[i] = row
+		i++
+		return nil
+	})
+}
+
+// newWallRow returns a new grid row made entirely of walls
+func newWallRow(width int) []bool {
+	row := make([]bool, width)
+	for i := range row {
+		row[i] = true
+	}
+	return row
+}
+
+// AlgorithmOptions holds optional tuning parameters for algorithms that support them
+type AlgorithmOptions struct {
+	// Strategy is the Growing Tree cell-selection strategy, e.g. "newest:75,random:25"
+	Strategy string
+	// Bias is the Binary Tree and Sidewinder bias corner: NE, NW, SE or SW
+	Bias string
+}
+
 // NewAlgorithm creates an algorithm instance by name
 func NewAlgorithm(algorithmName string) (Algorithm, error) {
+	return NewAlgorithmWithOptions(algorithmName, AlgorithmOptions{})
+}
+
+// NewAlgorithmWithOptions creates an algorithm instance by name with tuning options
+func NewAlgorithmWithOptions(algorithmName string, options AlgorithmOptions) (Algorithm, error) {
+	var algorithm Algorithm
 	switch algorithmName {
 	case "dfs":
-		return &DFSAlgorithm{}, nil
+		algorithm = &DFSAlgorithm{}
 	case "kruskal":
-		return &KruskalAlgorithm{}, nil
+		algorithm = &KruskalAlgorithm{}
 	case "wilson":
-		return &WilsonAlgorithm{}, nil
+		algorithm = &WilsonAlgorithm{}
+	case "prim":
+		algorithm = &PrimAlgorithm{}
+	case "eller":
+		algorithm = &EllerAlgorithm{}
+	case "division":
+		algorithm = &RecursiveDivisionAlgorithm{}
+	case "aldous-broder":
+		algorithm = &AldousBroderAlgorithm{}
+	case "hunt-and-kill":
+		algorithm = &HuntAndKillAlgorithm{}
+	case "growing-tree":
+		if options.Bias != "" {
+			return nil, fmt.Errorf("algorithm %s does not support a bias", algorithmName)
+		}
+		growingTree, err := NewGrowingTreeAlgorithm(options.Strategy)
+		if err != nil {
+			return nil, err
+		}
+		return growingTree, nil
+	case "binary-tree", "sidewinder":
+		if options.Strategy != "" {
+			return nil, fmt.Errorf("algorithm %s does not support a cell-selection strategy", algorithmName)
+		}
+		return newBiasedAlgorithm(algorithmName, options.Bias)
 	default:
-		return nil, fmt.Errorf("unknown algorithm: %s (supported: dfs, kruskal, wilson)", algorithmName)
+		return nil, fmt.Errorf("unknown algorithm: %s (supported: %s)", algorithmName, strings.Join(GetSupportedAlgorithms(), ", "))
 	}
+
+	if options.Strategy != "" {
+		return nil, fmt.Errorf("algorithm %s does not support a cell-selection strategy", algorithmName)
+	}
+	if options.Bias != "" {
+		return nil, fmt.Errorf("algorithm %s does not support a bias", algorithmName)
+	}
+	return algorithm, nil
+}
+
+// newBiasedAlgorithm creates one of the algorithms that take a bias corner
+func newBiasedAlgorithm(algorithmName, bias string) (Algorithm, error) {
+	if algorithmName == "sidewinder" {
+		sidewinder, err := NewSidewinderAlgorithm(bias)
+		if err != nil {
+			return nil, err
+		}
+		return sidewinder, nil
+	}
+
+	binaryTree, err := NewBinaryTreeAlgorithm(bias)
+	if err != nil {
+		return nil, err
+	}
+	return binaryTree, nil
 }
 
 // GetSupportedAlgorithms returns a list of supported algorithm names
 func GetSupportedAlgorithms() []string {
-	return []string{"dfs", "kruskal", "wilson"}
+	return []string{"dfs", "kruskal", "wilson", "prim", "growing-tree", "eller", "division", "binary-tree", "sidewinder", "aldous-broder", "hunt-and-kill"}
+}
+
+// graphAlgorithmNames returns the supported algorithms that are GraphAlgorithms
+func graphAlgorithmNames() []string {
+	var names []string
+	for _, name := range GetSupportedAlgorithms() {
+		algorithm, _ := NewAlgorithm(name)
+		if _, ok := algorithm.(GraphAlgorithm); ok {
+			names = append(names, name)
+		}
+	}
+	return names
 }
@@ -1,6 +1,8 @@
 package maze
 
 import (
+	"errors"
+	"fmt"
 	"math/rand"
 	"testing"
 	"time"
@@ -43,6 +45,89 @@ func TestNewAlgorithm(t *testing.T) {
 		t.Error("Expected WilsonAlgorithm instance")
 	}
 
+	// Test valid Prim algorithm
+	algorithm, err = NewAlgorithm("prim")
+	if err != nil {
+		t.Errorf("Expected no error for valid algorithm 'prim', got: %v", err)
+	}
+	if algorithm == nil {
+		t.Error("Expected algorithm instance, got nil")
+	}
+	if _, ok := algorithm.(*PrimAlgorithm); !ok {
+		t.Error("Expected PrimAlgorithm instance")
+	}
+
+	// Test valid Growing Tree algorithm
+	algorithm, err = NewAlgorithm("growing-tree")
+	if err != nil {
+		t.Errorf("Expected no error for valid algorithm 'growing-tree', got: %v", err)
+	}
+	if growingTree, ok := algorithm.(*GrowingTreeAlgorithm); !ok {
+		t.Error("Expected GrowingTreeAlgorithm instance")
+	} else if growingTree.String() != DefaultGrowingTreeStrategy {
+		t.Errorf("Expected default strategy %q, got %q", DefaultGrowingTreeStrategy, growingTree.String())
+	}
+
+	// Test valid Eller algorithm
+	algorithm, err = NewAlgorithm("eller")
+	if err != nil {
+		t.Errorf("Expected no error for valid algorithm 'eller', got: %v", err)
+	}
+	if _, ok := algorithm.(*EllerAlgorithm); !ok {
+		t.Error("Expected EllerAlgorithm instance")
+	}
+	if _, ok := algorithm.(RowGenerator); !ok {
+		t.Error("Expected EllerAlgorithm to implement RowGenerator")
+	}
+
+	// Test valid Recursive Division algorithm
+	algorithm, err = NewAlgorithm("division")
+	if err != nil {
+		t.Errorf("Expected no error for valid algorithm 'division', got: %v", err)
+	}
+	if _, ok := algorithm.(*RecursiveDivisionAlgorithm); !ok {
+		t.Error("Expected RecursiveDivisionAlgorithm instance")
+	}
+
+	// Test valid Binary Tree and Sidewinder algorithms with the default bias
+	algorithm, err = NewAlgorithm("binary-tree")
+	if err != nil {
+		t.Errorf("Expected no error for valid algorithm 'binary-tree', got: %v", err)
+	}
+	if binaryTree, ok := algorithm.(*BinaryTreeAlgorithm); !ok {
+		t.Error("Expected BinaryTreeAlgorithm instance")
+	} else if binaryTree.Bias().String() != DefaultBias {
+		t.Errorf("Expected default bias %s, got %s", DefaultBias, binaryTree.Bias())
+	}
+
+	algorithm, err = NewAlgorithm("sidewinder")
+	if err != nil {
+		t.Errorf("Expected no error for valid algorithm 'sidewinder', got: %v", err)
+	}
+	if sidewinder, ok := algorithm.(*SidewinderAlgorithm); !ok {
+		t.Error("Expected SidewinderAlgorithm instance")
+	} else if sidewinder.Bias().String() != DefaultBias {
+		t.Errorf("Expected default bias %s, got %s", DefaultBias, sidewinder.Bias())
+	}
+
+	// Test valid Aldous-Broder algorithm
+	algorithm, err = NewAlgorithm("aldous-broder")
+	if err != nil {
+		t.Errorf("Expected no error for valid algorithm 'aldous-broder', got: %v", err)
+	}
+	if _, ok := algorithm.(*AldousBroderAlgorithm); !ok {
+		t.Error("Expected AldousBroderAlgorithm instance")
+	}
+
+	// Test valid Hunt-and-Kill algorithm
+	algorithm, err = NewAlgorithm("hunt-and-kill")
+	if err != nil {
+		t.Errorf("Expected no error for valid algorithm 'hunt-and-kill', got: %v", err)
+	}
+	if _, ok := algorithm.(*HuntAndKillAlgorithm); !ok {
+		t.Error("Expected HuntAndKillAlgorithm instance")
+	}
+
 	// Test invalid algorithm
 	algorithm, err = NewAlgorithm("invalid")
 	if err == nil {
@@ -55,14 +140,22 @@ func TestNewAlgorithm(t *testing.T) {
 
 func TestGetSupportedAlgorithms(t *testing.T) {
 	algorithms := GetSupportedAlgorithms()
-	if len(algorithms) < 3 {
-		t.Error("Expected at least three supported algorithms")
+	if len(algorithms) < 4 {
+		t.Error("Expected at least four supported algorithms")
 	}
 
 	// Check that all algorithms are supported
 	dfsSupported := false
 	kruskalSupported := false
 	wilsonSupported := false
+	primSupported := false
+	growingTreeSupported := false
+	ellerSupported := false
+	divisionSupported := false
+	binaryTreeSupported := false
+	sidewinderSupported := false
+	aldousBroderSupported := false
+	huntAndKillSupported := false
 	for _, alg := range algorithms {
 		if alg == "dfs" {
 			dfsSupported = true
@@ -73,6 +166,30 @@ func TestGetSupportedAlgorithms(t *testing.T) {
 		if alg == "wilson" {
 			wilsonSupported = true
 		}
+		if alg == "prim" {
+			primSupported = true
+		}
+		if alg == "growing-tree" {
+			growingTreeSupported = true
+		}
+		if alg == "eller" {
+			ellerSupported = true
+		}
+		if alg == "division" {
+			divisionSupported = true
+		}
+		if alg == "binary-tree" {
+			binaryTreeSupported = true
+		}
+		if alg == "sidewinder" {
+			sidewinderSupported = true
+		}
+		if alg == "aldous-broder" {
+			aldousBroderSupported = true
+		}
+		if alg == "hunt-and-kill" {
+			huntAndKillSupported = true
+		}
 	}
 	if !dfsSupported {
 		t.Error("Expected 'dfs' to be in supported algorithms")
@@ -83,6 +200,30 @@ func TestGetSupportedAlgorithms(t *testing.T) {
 	if !wilsonSupported {
 		t.Error("Expected 'wilson' to be in supported algorithms")
 	}
+	if !primSupported {
+		t.Error("Expected 'prim' to be in supported algorithms")
+	}
+	if !growingTreeSupported {
+		t.Error("Expected 'growing-tree' to be in supported algorithms")
+	}
+	if !ellerSupported {
+		t.Error("Expected 'eller' to be in supported algorithms")
+	}
+	if !divisionSupported {
+		t.Error("Expected 'division' to be in supported algorithms")
+	}
+	if !binaryTreeSupported {
+		t.Error("Expected 'binary-tree' to be in supported algorithms")
+	}
+	if !sidewinderSupported {
+		t.Error("Expected 'sidewinder' to be in supported algorithms")
+	}
+	if !aldousBroderSupported {
+		t.Error("Expected 'aldous-broder' to be in supported algorithms")
+	}
+	if !huntAndKillSupported {
+		t.Error("Expected 'hunt-and-kill' to be in supported algorithms")
+	}
 }
 
 func TestDFSAlgorithmGenerate(t *testing.T) {
@@ -163,6 +304,92 @@ func TestDFSReproducibility(t *testing.T) {
 	}
 }
 
+// recursiveDFSReference is the original recursive DFS implementation. The
+// iterative DFSAlgorithm must reproduce its output exactly for every seed so
+// that previously generated mazes stay valid.
+func recursiveDFSReference(maze *Maze, row, col int, rng *rand.Rand) {
+	maze.Grid[row][col] = false
+
+	directions := [][2]int{{-2, 0}, {0, 2}, {2, 0}, {0, -2}}
+	for i := len(directions) - 1; i > 0; i-- {
+		j := rng.Intn(i + 1)
+		directions[i], directions[j] = directions[j], directions[i]
+	}
+
+	for _, dir := range directions {
+		newRow := row + dir[0]
+		newCol := col + dir[1]
+		if newRow > 0 && newRow < maze.Height-1 && newCol > 0 && newCol < maze.Width-1 && maze.Grid[newRow][newCol] {
+			maze.Grid[row+dir[0]/2][col+dir[1]/2] = false
+			recursiveDFSReference(maze, newRow, newCol, rng)
+		}
+	}
+}
+
+func TestDFSMatchesRecursiveReference(t *testing.T) {
+	dfs := &DFSAlgorithm{}
+	sizes := [][2]int{{5, 5}, {7, 7}, {21, 21}, {41, 11}, {11, 41}, {101, 101}}
+
+	for _, size := range sizes {
+		for seed := int64(0); seed < 50; seed++ {
+			expected := createTestMaze(size[0], size[1])
+			recursiveDFSReference(expected, 1, 1, rand.New(rand.NewSource(seed)))
+
+			actual := createTestMaze(size[0], size[1])
+			dfs.Generate(actual, 1, 1, rand.New(rand.NewSource(seed)))
+
+			if expected.String() != actual.String() {
+				t.Fatalf("DFS output for %dx%d seed %d differs from the recursive reference", size[0], size[1], seed)
+			}
+		}
+	}
+}
+
+func BenchmarkDFSGenerate(b *testing.B) {
+	for _, size := range []int{101, 1001, 5001, 10001} {
+		b.Run(fmt.Sprintf("%dx%d", size, size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				generator := NewGeneratorWithSeed("42")
+				_ = generator.Generate(size, size)
+			}
+		})
+	}
+}
+
+func TestAlgorithmsRectangular(t *testing.T) {
+	sizes := [][2]int{{79, 21}, {21, 79}, {5, 15}, {15, 5}}
+
+	for _, name := range GetSupportedAlgorithms() {
+		for _, size := range sizes {
+			t.Run(fmt.Sprintf("%s/%dx%d", name, size[0], size[1]), func(t *testing.T) {
+				generator, err := NewGeneratorWithSeedAndAlgorithm("42", name)
+				if err != nil {
+					t.Fatal(err)
+				}
+				maze := generator.Generate(size[0], size[1])
+				if maze.Width != size[0] || maze.Height != size[1] || len(maze.Grid) != size[1] || len(maze.Grid[0]) != size[0] {
+					t.Fatalf("Expected a %dx%d maze, got %dx%d", size[0], size[1], maze.Width, maze.Height)
+				}
+				checkPerfectMaze(t, maze)
+			})
+		}
+	}
+}
+
+func BenchmarkAlgorithms(b *testing.B) {
+	for _, name := range GetSupportedAlgorithms() {
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				generator, err := NewGeneratorWithSeedAndAlgorithm("42", name)
+				if err != nil {
+					b.Fatal(err)
+				}
+				_ = generator.Generate(201, 201)
+			}
+		})
+	}
+}
+
 func TestKruskalAlgorithmGenerate(t *testing.T) {
 	kruskal := &KruskalAlgorithm{}
 	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
@@ -383,6 +610,737 @@ func TestWilsonConnectivity(t *testing.T) {
 	}
 }
 
+// wilsonListReference is the original list-based Wilson implementation, which
+// rescanned the remaining cells after every walk. The Fenwick-tree version must
+// reproduce its output exactly so that previously generated mazes stay valid.
+func wilsonListReference(maze *Maze, startRow, startCol int, rng *rand.Rand) {
+	inMaze := make([][]bool, maze.Height)
+	for i := range inMaze {
+		inMaze[i] = make([]bool, maze.Width)
+	}
+	maze.Grid[startRow][startCol] = false
+	inMaze[startRow][startCol] = true
+
+	var cells [][2]int
+	for row := 1; row < maze.Height; row += 2 {
+		for col := 1; col < maze.Width; col += 2 {
+			if row != startRow || col != startCol {
+				cells = append(cells, [2]int{row, col})
+			}
+		}
+	}
+
+	for len(cells) > 0 {
+		cell := cells[rng.Intn(len(cells))]
+
+		// Loop-erased random walk
+		path := [][2]int{}
+		for pos := cell; ; {
+			if inMaze[pos[0]][pos[1]] {
+				path = append(path, pos)
+				break
+			}
+			for i, p := range path {
+				if p == pos {
+					path = path[:i]
+					break
+				}
+			}
+			path = append(path, pos)
+
+			var valid [][2]int
+			for _, dir := range [][2]int{{-2, 0}, {0, 2}, {2, 0}, {0, -2}} {
+				row, col := pos[0]+dir[0], pos[1]+dir[1]
+				if row > 0 && row < maze.Height-1 && col > 0 && col < maze.Width-1 {
+					valid = append(valid, dir)
+				}
+			}
+			dir := valid[rng.Intn(len(valid))]
+			pos = [2]int{pos[0] + dir[0], pos[1] + dir[1]}
+		}
+
+		for i, pos := range path {
+			maze.Grid[pos[0]][pos[1]] = false
+			inMaze[pos[0]][pos[1]] = true
+			if i > 0 {
+				maze.Grid[(pos[0]+path[i-1][0])/2][(pos[1]+path[i-1][1])/2] = false
+			}
+		}
+
+		remaining := cells[:0]
+		for _, c := range cells {
+			if !inMaze[c[0]][c[1]] {
+				remaining = append(remaining, c)
+			}
+		}
+		cells = remaining
+	}
+}
+
+func TestWilsonMatchesListReference(t *testing.T) {
+	wilson := &WilsonAlgorithm{}
+	sizes := [][2]int{{5, 5}, {7, 7}, {21, 21}, {41, 11}, {11, 41}, {61, 61}}
+
+	for _, size := range sizes {
+		for seed := int64(0); seed < 50; seed++ {
+			expected := createTestMaze(size[0], size[1])
+			wilsonListReference(expected, 1, 1, rand.New(rand.NewSource(seed)))
+
+			actual := createTestMaze(size[0], size[1])
+			wilson.Generate(actual, 1, 1, rand.New(rand.NewSource(seed)))
+
+			if expected.String() != actual.String() {
+				t.Fatalf("Wilson output for %dx%d seed %d differs from the list-based reference", size[0], size[1], seed)
+			}
+		}
+	}
+}
+
+func BenchmarkWilsonGenerate(b *testing.B) {
+	for _, size := range []int{101, 401, 1001} {
+		b.Run(fmt.Sprintf("%dx%d", size, size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				generator, err := NewGeneratorWithSeedAndAlgorithm("42", "wilson")
+				if err != nil {
+					b.Fatal(err)
+				}
+				_ = generator.Generate(size, size)
+			}
+		})
+	}
+}
+
+func TestPrimAlgorithmGenerate(t *testing.T) {
+	prim := &PrimAlgorithm{}
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	// Create a test maze
+	width, height := 7, 7
+	maze := createTestMaze(width, height)
+
+	// Generate maze using Prim's algorithm
+	prim.Generate(maze, 1, 1, rng)
+
+	// Verify that every cell at odd coordinates was carved
+	for i := 1; i < height-1; i += 2 {
+		for j := 1; j < width-1; j += 2 {
+			if maze.Grid[i][j] {
+				t.Errorf("Expected cell (%d, %d) to be a path, got wall", i, j)
+			}
+		}
+	}
+
+	// Verify boundaries are still walls
+	for i := 0; i < height; i++ {
+		if !maze.Grid[i][0] || !maze.Grid[i][width-1] {
+			t.Error("Expected boundaries to remain walls")
+		}
+	}
+	for j := 0; j < width; j++ {
+		if !maze.Grid[0][j] || !maze.Grid[height-1][j] {
+			t.Error("Expected boundaries to remain walls")
+		}
+	}
+}
+
+func TestPrimReproducibility(t *testing.T) {
+	prim := &PrimAlgorithm{}
+	seed := int64(12345)
+
+	// Generate first maze
+	rng1 := rand.New(rand.NewSource(seed))
+	maze1 := createTestMaze(7, 7)
+	prim.Generate(maze1, 1, 1, rng1)
+
+	// Generate second maze with same seed
+	rng2 := rand.New(rand.NewSource(seed))
+	maze2 := createTestMaze(7, 7)
+	prim.Generate(maze2, 1, 1, rng2)
+
+	// Compare mazes
+	for i := 0; i < maze1.Height; i++ {
+		for j := 0; j < maze1.Width; j++ {
+			if maze1.Grid[i][j] != maze2.Grid[i][j] {
+				t.Errorf("Mazes differ at position (%d, %d)", i, j)
+			}
+		}
+	}
+}
+
+func TestPrimConnectivity(t *testing.T) {
+	prim := &PrimAlgorithm{}
+	rng := rand.New(rand.NewSource(42))
+
+	// Create a larger test maze
+	width, height := 9, 9
+	maze := createTestMaze(width, height)
+
+	// Generate maze using Prim's algorithm
+	prim.Generate(maze, 1, 1, rng)
+
+	// Verify connectivity using flood fill from start position
+	visited := make([][]bool, height)
+	for i := range visited {
+		visited[i] = make([]bool, width)
+	}
+
+	// Flood fill from position (1,1)
+	floodFill(maze, visited, 1, 1)
+
+	// Count reachable path cells
+	reachableCount := 0
+	totalPathCount := 0
+	for i := 1; i < height-1; i++ {
+		for j := 1; j < width-1; j++ {
+			if !maze.Grid[i][j] { // It's a path
+				totalPathCount++
+				if visited[i][j] {
+					reachableCount++
+				}
+			}
+		}
+	}
+
+	// All path cells should be reachable
+	if reachableCount != totalPathCount {
+		t.Errorf("Not all paths are connected: reachable=%d, total=%d", reachableCount, totalPathCount)
+	}
+
+	// A perfect maze on n cells has exactly n-1 passages between them
+	cellCount := ((width - 1) / 2) * ((height - 1) / 2)
+	if totalPathCount != 2*cellCount-1 {
+		t.Errorf("Expected %d path cells for a perfect maze, got %d", 2*cellCount-1, totalPathCount)
+	}
+}
+
+func TestNewAlgorithmWithOptions(t *testing.T) {
+	// Strategy is passed through to Growing Tree
+	algorithm, err := NewAlgorithmWithOptions("growing-tree", AlgorithmOptions{Strategy: "oldest"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if growingTree, ok := algorithm.(*GrowingTreeAlgorithm); !ok || growingTree.String() != "oldest" {
+		t.Errorf("Expected Growing Tree with strategy 'oldest', got %v", algorithm)
+	}
+
+	// Strategy is rejected for algorithms that do not use it
+	algorithm, err = NewAlgorithmWithOptions("dfs", AlgorithmOptions{Strategy: "oldest"})
+	if err == nil {
+		t.Error("Expected error when passing a strategy to dfs")
+	}
+	if algorithm != nil {
+		t.Error("Expected nil algorithm on error")
+	}
+
+	// Bias is passed through to Binary Tree and Sidewinder
+	algorithm, err = NewAlgorithmWithOptions("sidewinder", AlgorithmOptions{Bias: "sw"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if sidewinder, ok := algorithm.(*SidewinderAlgorithm); !ok || sidewinder.Bias().String() != "SW" {
+		t.Errorf("Expected Sidewinder with bias SW, got %v", algorithm)
+	}
+
+	// Bias is rejected for algorithms that do not use it
+	for _, name := range []string{"dfs", "growing-tree"} {
+		algorithm, err = NewAlgorithmWithOptions(name, AlgorithmOptions{Bias: "NE"})
+		if err == nil || algorithm != nil {
+			t.Errorf("Expected error and nil algorithm when passing a bias to %s", name)
+		}
+	}
+
+	// Strategy is rejected for biased algorithms
+	algorithm, err = NewAlgorithmWithOptions("binary-tree", AlgorithmOptions{Strategy: "newest"})
+	if err == nil || algorithm != nil {
+		t.Error("Expected error and nil algorithm when passing a strategy to binary-tree")
+	}
+
+	// Invalid bias yields a nil algorithm, not a typed nil
+	algorithm, err = NewAlgorithmWithOptions("binary-tree", AlgorithmOptions{Bias: "up"})
+	if err == nil || algorithm != nil {
+		t.Error("Expected error and nil algorithm for invalid bias")
+	}
+
+	// Invalid strategy yields a nil algorithm, not a typed nil
+	algorithm, err = NewAlgorithmWithOptions("growing-tree", AlgorithmOptions{Strategy: "bogus"})
+	if err == nil {
+		t.Error("Expected error for invalid strategy")
+	}
+	if algorithm != nil {
+		t.Error("Expected nil algorithm on error")
+	}
+}
+
+func TestNewGrowingTreeAlgorithm(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy string
+		want     string
+		wantErr  bool
+	}{
+		{name: "empty uses default", strategy: "", want: "newest"},
+		{name: "newest", strategy: "newest", want: "newest"},
+		{name: "oldest", strategy: "oldest", want: "oldest"},
+		{name: "random", strategy: "random", want: "random"},
+		{name: "weighted mix", strategy: "newest:75,random:25", want: "newest:75,random:25"},
+		{name: "mix without weights", strategy: "newest, random", want: "newest:1,random:1"},
+		{name: "unknown strategy", strategy: "sideways", wantErr: true},
+		{name: "zero weight", strategy: "newest:0", wantErr: true},
+		{name: "non-numeric weight", strategy: "newest:lots", wantErr: true},
+		{name: "empty part", strategy: "newest,", wantErr: true},
+		{name: "largest weight", strategy: "newest:2147483647", want: "newest"},
+		{name: "weight too large", strategy: "newest:9223372036854775807,random:1", wantErr: true},
+		{name: "total weight too large", strategy: "newest:2147483647,random:1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			growingTree, err := NewGrowingTreeAlgorithm(tt.strategy)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error for strategy %q, got none", tt.strategy)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error for strategy %q: %v", tt.strategy, err)
+			}
+			if growingTree.String() != tt.want {
+				t.Errorf("Expected normalized strategy %q, got %q", tt.want, growingTree.String())
+			}
+		})
+	}
+}
+
+func TestGrowingTreeAlgorithmGenerate(t *testing.T) {
+	for _, strategy := range []string{"newest", "oldest", "random", "newest:75,random:25"} {
+		t.Run(strategy, func(t *testing.T) {
+			growingTree, err := NewGrowingTreeAlgorithm(strategy)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			rng := rand.New(rand.NewSource(42))
+
+			// Create a test maze
+			width, height := 9, 9
+			maze := createTestMaze(width, height)
+
+			// Generate maze using the Growing Tree algorithm
+			growingTree.Generate(maze, 1, 1, rng)
+
+			// Verify connectivity using flood fill from start position
+			visited := make([][]bool, height)
+			for i := range visited {
+				visited[i] = make([]bool, width)
+			}
+			floodFill(maze, visited, 1, 1)
+
+			reachableCount := 0
+			totalPathCount := 0
+			for i := 1; i < height-1; i++ {
+				for j := 1; j < width-1; j++ {
+					if !maze.Grid[i][j] {
+						totalPathCount++
+						if visited[i][j] {
+							reachableCount++
+						}
+					}
+				}
+			}
+			if reachableCount != totalPathCount {
+				t.Errorf("Not all paths are connected: reachable=%d, total=%d", reachableCount, totalPathCount)
+			}
+
+			// A perfect maze on n cells has exactly n-1 passages between them
+			cellCount := ((width - 1) / 2) * ((height - 1) / 2)
+			if totalPathCount != 2*cellCount-1 {
+				t.Errorf("Expected %d path cells for a perfect maze, got %d", 2*cellCount-1, totalPathCount)
+			}
+		})
+	}
+}
+
+func TestActiveCells(t *testing.T) {
+	active := &activeCells{}
+	for i := 0; i < 6; i++ {
+		active.add(i)
+	}
+
+	// Retiring cells anywhere in the list keeps the others in order
+	for _, index := range []int{2, 0, 5, 3} {
+		active.retire(index)
+	}
+	var remaining []int
+	for i := active.head; i < len(active.cells); i++ {
+		if !active.retired[i] {
+			remaining = append(remaining, active.cells[i])
+		}
+	}
+	if active.count != 2 || len(remaining) != 2 || remaining[0] != 1 || remaining[1] != 4 {
+		t.Fatalf("Expected cells 1 and 4 to remain in order, got %v", remaining)
+	}
+	if active.cells[active.head] != 1 || active.cells[len(active.cells)-1] != 4 {
+		t.Errorf("Expected the oldest and newest cells at the ends of the list, got %v", active.cells[active.head:])
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		if index := active.random(rng); active.retired[index] {
+			t.Fatalf("Expected random to pick an active cell, got retired index %d", index)
+		}
+	}
+
+	active.retire(active.head)
+	active.retire(len(active.cells) - 1)
+	if active.count != 0 || len(active.cells) != active.head {
+		t.Errorf("Expected an empty list, got %d cells from %d", len(active.cells), active.head)
+	}
+}
+
+func TestGrowingTreeReproducibility(t *testing.T) {
+	generate := func(strategy string, seed int64) *Maze {
+		growingTree, err := NewGrowingTreeAlgorithm(strategy)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		maze := createTestMaze(15, 15)
+		growingTree.Generate(maze, 1, 1, rand.New(rand.NewSource(seed)))
+		return maze
+	}
+
+	// Same seed and strategy produce the same maze
+	maze1 := generate("newest:75,random:25", 12345)
+	maze2 := generate("newest:75,random:25", 12345)
+	if maze1.String() != maze2.String() {
+		t.Error("Same seed and strategy should produce identical mazes")
+	}
+
+	// The strategy is part of what identifies a seeded maze
+	maze3 := generate("newest:25,random:75", 12345)
+	if maze1.String() == maze3.String() {
+		t.Error("Different strategies with the same seed should produce different mazes")
+	}
+}
+
+func TestEllerAlgorithmGenerate(t *testing.T) {
+	eller := &EllerAlgorithm{}
+
+	// Eller's algorithm must handle square, wide and tall grids
+	sizes := [][2]int{{5, 5}, {9, 9}, {21, 7}, {7, 21}}
+	for _, size := range sizes {
+		for seed := int64(1); seed <= 20; seed++ {
+			maze := createTestMaze(size[0], size[1])
+			eller.Generate(maze, 1, 1, rand.New(rand.NewSource(seed)))
+			checkPerfectMaze(t, maze)
+		}
+	}
+}
+
+func TestEllerReproducibility(t *testing.T) {
+	eller := &EllerAlgorithm{}
+	seed := int64(12345)
+
+	maze1 := createTestMaze(11, 11)
+	eller.Generate(maze1, 1, 1, rand.New(rand.NewSource(seed)))
+
+	maze2 := createTestMaze(11, 11)
+	eller.Generate(maze2, 1, 1, rand.New(rand.NewSource(seed)))
+
+	if maze1.String() != maze2.String() {
+		t.Error("Same seed should produce identical mazes")
+	}
+}
+
+func TestEllerGenerateRowsStopsOnError(t *testing.T) {
+	eller := &EllerAlgorithm{}
+	errStop := errors.New("stop")
+
+	rows := 0
+	err := eller.GenerateRows(9, 9, rand.New(rand.NewSource(1)), func(row []bool) error {
+		rows++
+		if rows == 3 {
+			return errStop
+		}
+		return nil
+	})
+	if !errors.Is(err, errStop) {
+		t.Errorf("Expected emit error to be returned, got %v", err)
+	}
+	if rows != 3 {
+		t.Errorf("Expected generation to stop after 3 rows, got %d", rows)
+	}
+}
+
+func TestRecursiveDivisionAlgorithmGenerate(t *testing.T) {
+	division := &RecursiveDivisionAlgorithm{}
+
+	sizes := [][2]int{{5, 5}, {9, 9}, {21, 7}, {7, 21}, {31, 31}}
+	for _, size := range sizes {
+		for seed := int64(1); seed <= 20; seed++ {
+			maze := createTestMaze(size[0], size[1])
+			division.Generate(maze, 1, 1, rand.New(rand.NewSource(seed)))
+			checkPerfectMaze(t, maze)
+		}
+	}
+}
+
+func TestRecursiveDivisionReproducibility(t *testing.T) {
+	division := &RecursiveDivisionAlgorithm{}
+	seed := int64(12345)
+
+	maze1 := createTestMaze(15, 15)
+	division.Generate(maze1, 1, 1, rand.New(rand.NewSource(seed)))
+
+	maze2 := createTestMaze(15, 15)
+	division.Generate(maze2, 1, 1, rand.New(rand.NewSource(seed)))
+
+	if maze1.String() != maze2.String() {
+		t.Error("Same seed should produce identical mazes")
+	}
+
+	maze3 := createTestMaze(15, 15)
+	division.Generate(maze3, 1, 1, rand.New(rand.NewSource(seed+1)))
+	if maze1.String() == maze3.String() {
+		t.Error("Different seeds should produce different mazes")
+	}
+}
+
+func TestRecursiveDivisionSolvable(t *testing.T) {
+	generator, err := NewGeneratorWithSeedAndAlgorithm("42", "division")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	maze := generator.Generate(21, 15)
+
+	path := FindPath(maze)
+	if path == nil {
+		t.Fatal("Expected FindPath to solve a recursive division maze")
+	}
+	if path[len(path)-1] != (Position{Row: maze.GoalRow, Col: maze.GoalCol}) {
+		t.Errorf("Expected path to end at goal, got %v", path[len(path)-1])
+	}
+}
+
+func TestParseBias(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Bias
+		wantErr bool
+	}{
+		{input: "", want: Bias{North: true, East: true}},
+		{input: "NE", want: Bias{North: true, East: true}},
+		{input: "nw", want: Bias{North: true, East: false}},
+		{input: "Se", want: Bias{North: false, East: true}},
+		{input: "SW", want: Bias{North: false, East: false}},
+		{input: "EN", wantErr: true},
+		{input: "N", wantErr: true},
+		{input: "NEE", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		bias, err := ParseBias(tt.input)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Expected error for bias %q, got none", tt.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error for bias %q: %v", tt.input, err)
+			continue
+		}
+		if bias != tt.want {
+			t.Errorf("Expected %v for bias %q, got %v", tt.want, tt.input, bias)
+		}
+	}
+}
+
+func TestBiasedAlgorithmsGenerate(t *testing.T) {
+	sizes := [][2]int{{5, 5}, {9, 9}, {21, 7}, {7, 21}}
+	for _, bias := range []string{"NE", "NW", "SE", "SW"} {
+		binaryTree, err := NewBinaryTreeAlgorithm(bias)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		sidewinder, err := NewSidewinderAlgorithm(bias)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		for _, algorithm := range []Algorithm{binaryTree, sidewinder} {
+			for _, size := range sizes {
+				for seed := int64(1); seed <= 10; seed++ {
+					maze := createTestMaze(size[0], size[1])
+					algorithm.Generate(maze, 1, 1, rand.New(rand.NewSource(seed)))
+					checkPerfectMaze(t, maze)
+					checkBiasCorridors(t, maze, algorithm, bias)
+				}
+			}
+		}
+	}
+}
+
+// checkBiasCorridors verifies the unbroken corridors a biased algorithm leaves
+// along the edges that meet at its bias corner
+func checkBiasCorridors(t *testing.T, maze *Maze, algorithm Algorithm, bias string) {
+	t.Helper()
+
+	// Both algorithms leave the whole bias row open
+	row := 1
+	if bias[0] == 'S' {
+		row = maze.Height - 2
+	}
+	for col := 1; col < maze.Width-1; col++ {
+		if maze.Grid[row][col] {
+			t.Fatalf("%T with bias %s: expected row %d to be an open corridor", algorithm, bias, row)
+		}
+	}
+
+	// Binary Tree also leaves the whole bias column open
+	if _, ok := algorithm.(*BinaryTreeAlgorithm); ok {
+		col := maze.Width - 2
+		if bias[1] == 'W' {
+			col = 1
+		}
+		for row := 1; row < maze.Height-1; row++ {
+			if maze.Grid[row][col] {
+				t.Fatalf("Binary Tree with bias %s: expected column %d to be an open corridor", bias, col)
+			}
+		}
+	}
+}
+
+func TestBiasedAlgorithmsReproducibility(t *testing.T) {
+	for _, name := range []string{"binary-tree", "sidewinder"} {
+		algorithm, err := NewAlgorithmWithOptions(name, AlgorithmOptions{Bias: "SE"})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		maze1 := createTestMaze(15, 15)
+		algorithm.Generate(maze1, 1, 1, rand.New(rand.NewSource(12345)))
+
+		maze2 := createTestMaze(15, 15)
+		algorithm.Generate(maze2, 1, 1, rand.New(rand.NewSource(12345)))
+
+		if maze1.String() != maze2.String() {
+			t.Errorf("%s: same seed should produce identical mazes", name)
+		}
+	}
+}
+
+func TestAldousBroderAndHuntAndKillGenerate(t *testing.T) {
+	sizes := [][2]int{{5, 5}, {9, 9}, {21, 7}, {7, 21}}
+	for _, algorithm := range []Algorithm{&AldousBroderAlgorithm{}, &HuntAndKillAlgorithm{}} {
+		for _, size := range sizes {
+			for seed := int64(1); seed <= 10; seed++ {
+				maze := createTestMaze(size[0], size[1])
+				algorithm.Generate(maze, 1, 1, rand.New(rand.NewSource(seed)))
+				checkPerfectMaze(t, maze)
+			}
+		}
+	}
+}
+
+func TestAldousBroderAndHuntAndKillReproducibility(t *testing.T) {
+	for _, algorithm := range []Algorithm{&AldousBroderAlgorithm{}, &HuntAndKillAlgorithm{}} {
+		maze1 := createTestMaze(15, 15)
+		algorithm.Generate(maze1, 1, 1, rand.New(rand.NewSource(12345)))
+
+		maze2 := createTestMaze(15, 15)
+		algorithm.Generate(maze2, 1, 1, rand.New(rand.NewSource(12345)))
+
+		if maze1.String() != maze2.String() {
+			t.Errorf("%T: same seed should produce identical mazes", algorithm)
+		}
+	}
+}
+
+// Test that both uniform spanning tree generators pick every maze equally often.
+// A 2x3-cell grid has exactly 15 spanning trees.
+func TestUniformSpanningTreeAlgorithms(t *testing.T) {
+	const samples = 15000
+	for _, algorithm := range []Algorithm{&AldousBroderAlgorithm{}, &WilsonAlgorithm{}} {
+		rng := rand.New(rand.NewSource(7))
+		counts := make(map[string]int)
+		for i := 0; i < samples; i++ {
+			maze := createTestMaze(7, 5)
+			algorithm.Generate(maze, 1, 1, rng)
+			counts[maze.String()]++
+		}
+
+		if len(counts) != 15 {
+			t.Errorf("%T: expected all 15 spanning trees, got %d", algorithm, len(counts))
+		}
+		for maze, count := range counts {
+			// Expected 1000 each; 150 is about five standard deviations
+			if count < 850 || count > 1150 {
+				t.Errorf("%T: maze generated %d times, expected about 1000:\n%s", algorithm, count, maze)
+			}
+		}
+	}
+}
+
+// checkPerfectMaze verifies that every cell is carved, the boundary is intact,
+// all paths are connected, and there are exactly n-1 passages between n cells
+func checkPerfectMaze(t *testing.T, maze *Maze) {
+	t.Helper()
+
+	for i := 0; i < maze.Height; i++ {
+		if !maze.Grid[i][0] || !maze.Grid[i][maze.Width-1] {
+			t.Fatal("Expected boundaries to remain walls")
+		}
+	}
+	for j := 0; j < maze.Width; j++ {
+		if !maze.Grid[0][j] || !maze.Grid[maze.Height-1][j] {
+			t.Fatal("Expected boundaries to remain walls")
+		}
+	}
+
+	// Every enabled cell is a path; cells outside a mask stay walls
+	cellCount := 0
+	var first *Position
+	for i := 1; i < maze.Height-1; i += 2 {
+		for j := 1; j < maze.Width-1; j += 2 {
+			if !maze.cellEnabled(i, j) {
+				if !maze.Grid[i][j] {
+					t.Fatalf("Expected masked-out cell (%d, %d) to be a wall, got path", i, j)
+				}
+				continue
+			}
+			if first == nil {
+				first = &Position{Row: i, Col: j}
+			}
+			cellCount++
+			if maze.Grid[i][j] {
+				t.Fatalf("Expected cell (%d, %d) to be a path, got wall", i, j)
+			}
+		}
+	}
+
+	visited := make([][]bool, maze.Height)
+	for i := range visited {
+		visited[i] = make([]bool, maze.Width)
+	}
+	floodFill(maze, visited, first.Row, first.Col)
+
+	pathCount := 0
+	for i := 1; i < maze.Height-1; i++ {
+		for j := 1; j < maze.Width-1; j++ {
+			if !maze.Grid[i][j] {
+				pathCount++
+				if !visited[i][j] {
+					t.Fatalf("Path cell (%d, %d) is not connected to the start", i, j)
+				}
+			}
+		}
+	}
+
+	if pathCount != 2*cellCount-1 {
+		t.Fatalf("Expected %d path cells for a perfect maze, got %d", 2*cellCount-1, pathCount)
+	}
+}
+
 // Helper function to create a test maze with all walls
 func createTestMaze(width, height int) *Maze {
 	grid := make([][]bool, height)
@@ -2,13 +2,18 @@
 // This file implements ASCII rendering for maze output.
 package maze
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+)
 
 // ASCIIRenderer renders mazes using standard ASCII characters.
 type ASCIIRenderer struct{}
 
 // Render generates an ASCII representation of the maze.
 // Uses '#' for walls, ' ' for paths, '●' for start, '○' for goal, and '·' for solution path.
+// Passages that wrap around to the opposite edge are marked '<', '>', '^' or 'v' on the border,
+// and the crossings of weave mazes '-' or '|' after the corridor on top.
 func (r *ASCIIRenderer) Render(m *Maze) string {
 	var sb strings.Builder
 
@@ -19,22 +24,122 @@ func (r *ASCIIRenderer) Render(m *Maze) string {
 	}
 
 	for i, row := range m.Grid {
-		for j, cell := range row {
-			currentPos := Position{Row: i, Col: j}
-
-			if i == m.StartRow && j == m.StartCol {
-				sb.WriteRune('●') // Filled circle for start
-			} else if i == m.GoalRow && j == m.GoalCol {
-				sb.WriteRune('○') // Empty circle for goal
-			} else if len(m.SolutionPath) > 0 && solutionSet[currentPos] {
-				sb.WriteRune('·') // Solution path marker
-			} else if cell {
-				sb.WriteRune('#')
-			} else {
-				sb.WriteRune(' ')
-			}
+		r.writeRow(&sb, m, i, row, solutionSet)
+	}
+	return sb.String()
+}
+
+// asciiBridges show which corridor runs on top at a crossing
+var asciiBridges = map[Crossing]rune{CrossingHorizontal: '-', CrossingVertical: '|'}
+
+// asciiWrapMarkers point out of the maze where a passage wraps around
+var asciiWrapMarkers = map[Side]rune{SideTop: '^', SideRight: '>', SideBottom: 'v', SideLeft: '<'}
+
+// RenderRow implements the RowRenderer interface.
+// ASCII output only depends on the row itself, so above and below are ignored.
+func (r *ASCIIRenderer) RenderRow(m *Maze, i int, above, row, below []bool) string {
+	var sb strings.Builder
+
+	solutionSet := make(map[Position]bool)
+	for _, pos := range m.SolutionPath {
+		if pos.Row == i {
+			solutionSet[pos] = true
 		}
-		sb.WriteRune('\n')
 	}
+
+	r.writeRow(&sb, m, i, row, solutionSet)
 	return sb.String()
 }
+
+// writeRow writes a single grid row followed by a newline
+func (r *ASCIIRenderer) writeRow(sb *strings.Builder, m *Maze, i int, row []bool, solutionSet map[Position]bool) {
+	for j, cell := range row {
+		currentPos := Position{Row: i, Col: j}
+
+		if i == m.StartRow && j == m.StartCol {
+			sb.WriteRune('●') // Filled circle for start
+		} else if i == m.GoalRow && j == m.GoalCol {
+			sb.WriteRune('○') // Empty circle for goal
+		} else if crossing, ok := m.Crossings[currentPos]; ok {
+			sb.WriteRune(asciiBridges[crossing]) // Also on the solution, so the crossing stays visible
+		} else if len(solutionSet) > 0 && solutionSet[currentPos] {
+			sb.WriteRune('·') // Solution path marker
+		} else if side := m.wrapSide(currentPos); side != SideNone {
+			sb.WriteRune(asciiWrapMarkers[side])
+		} else if cell && m.outsideMask(i, j) {
+			sb.WriteRune(' ') // Blank outside the mask, so the silhouette shows
+		} else if cell {
+			sb.WriteRune('#')
+		} else {
+			sb.WriteRune(' ')
+		}
+	}
+	sb.WriteRune('\n')
+}
+
+// RenderHex implements the HexRenderer interface with '_', '/' and '\' walls
+// and the same markers as Render.
+func (r *ASCIIRenderer) RenderHex(m *HexMaze) string {
+	return renderHexText(m, hexGlyphs{flat: '_', rising: '/', falling: '\\', start: '●', goal: '○', solution: '·'})
+}
+
+// RenderMultiLevel implements the MultiLevelRenderer interface. Stairs are
+// marked '<' going up, '>' going down and 'X' going both ways.
+func (r *ASCIIRenderer) RenderMultiLevel(m *MultiLevelMaze, sideBySide bool) string {
+	return renderMultiLevelText(m, r, levelGlyphs{start: '●', goal: '○', up: '<', down: '>', both: 'X'}, sideBySide)
+}
+
+// RenderCube implements the CubeRenderer interface by drawing the net of the cube
+func (r *ASCIIRenderer) RenderCube(m *CubeMaze) string {
+	return r.Render(m.Net())
+}
+
+// RenderGraph implements the GraphRenderer interface by writing the passages
+// of the maze as a Graphviz DOT graph, with the start green, the goal red and
+// the solution blue. Placed nodes keep their positions, pinned with '!'.
+func (r *ASCIIRenderer) RenderGraph(m *GraphMaze) string {
+	onPath := make(map[[2]int]bool)
+	for i := 1; i < len(m.SolutionPath); i++ {
+		a, b := m.SolutionPath[i-1], m.SolutionPath[i]
+		onPath[[2]int{min(a, b), max(a, b)}] = true
+	}
+
+	var sb strings.Builder
+	sb.WriteString("graph maze {\n")
+	for i, node := range m.Nodes {
+		var attributes []string
+		if node.Placed {
+			attributes = append(attributes, fmt.Sprintf(`pos="%s,%s!"`, svgNumber(node.X), svgNumber(0-node.Y)))
+		}
+		switch i {
+		case m.Start:
+			attributes = append(attributes, `color="green"`)
+		case m.Goal:
+			attributes = append(attributes, `color="red"`)
+		}
+		sb.WriteString("  " + dotID(node.ID))
+		if len(attributes) > 0 {
+			sb.WriteString(" [" + strings.Join(attributes, ", ") + "]")
+		}
+		sb.WriteString(";\n")
+	}
+	for i, edge := range m.Edges {
+		if !m.Open(i) {
+			continue
+		}
+		sb.WriteString("  " + dotID(m.Nodes[edge.From].ID) + " -- " + dotID(m.Nodes[edge.To].ID))
+		if onPath[[2]int{min(edge.From, edge.To), max(edge.From, edge.To)}] {
+			sb.WriteString(` [color="blue"]`)
+		}
+		sb.WriteString(";\n")
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// dotID quotes a node ID for DOT output, escaping backslashes before quotes
+// so that the escapes added for quotes are not escaped again
+func dotID(id string) string {
+	id = strings.ReplaceAll(id, `\`, `\\`)
+	return `"` + strings.ReplaceAll(id, `"`, `\"`) + `"`
+}
@@ -0,0 +1,59 @@
+package maze
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultBias is the corner Binary Tree and Sidewinder lean toward by default
+const DefaultBias = "NE"
+
+// Bias selects the corner that biased algorithms such as Binary Tree and
+// Sidewinder lean toward: passages are only ever carved toward that corner,
+// so the two edges meeting there become long unbroken corridors.
+type Bias struct {
+	North bool // true = north, false = south
+	East  bool // true = east, false = west
+}
+
+// ParseBias parses a bias corner such as "NE", "nw", "SE" or "sw".
+// An empty string selects DefaultBias.
+func ParseBias(s string) (Bias, error) {
+	if s == "" {
+		s = DefaultBias
+	}
+
+	upper := strings.ToUpper(s)
+	if len(upper) != 2 || !strings.ContainsRune("NS", rune(upper[0])) || !strings.ContainsRune("EW", rune(upper[1])) {
+		return Bias{}, fmt.Errorf("invalid bias: %q (supported: NE, NW, SE, SW)", s)
+	}
+	return Bias{North: upper[0] == 'N', East: upper[1] == 'E'}, nil
+}
+
+// String returns the bias corner, e.g. "NE"
+func (b Bias) String() string {
+	vertical, horizontal := "S", "W"
+	if b.North {
+		vertical = "N"
+	}
+	if b.East {
+		horizontal = "E"
+	}
+	return vertical + horizontal
+}
+
+// rowStep returns the cell row offset toward the bias (-1 north, +1 south)
+func (b Bias) rowStep() int {
+	if b.North {
+		return -1
+	}
+	return 1
+}
+
+// colStep returns the cell column offset toward the bias (+1 east, -1 west)
+func (b Bias) colStep() int {
+	if b.East {
+		return 1
+	}
+	return -1
+}
@@ -0,0 +1,77 @@
+package maze
+
+import "math/rand"
+
+// BinaryTreeAlgorithm implements maze generation using the Binary Tree algorithm.
+// Every cell independently carves a passage toward one of the two bias
+// directions, so the maze can be built cell by cell with no memory of the rest.
+type BinaryTreeAlgorithm struct {
+	bias Bias
+}
+
+// NewBinaryTreeAlgorithm creates a Binary Tree algorithm leaning toward the given
+// bias corner (NE, NW, SE or SW). An empty string selects DefaultBias.
+func NewBinaryTreeAlgorithm(bias string) (*BinaryTreeAlgorithm, error) {
+	b, err := ParseBias(bias)
+	if err != nil {
+		return nil, err
+	}
+	return &BinaryTreeAlgorithm{bias: b}, nil
+}
+
+// Bias returns the corner the algorithm leans toward
+func (b *BinaryTreeAlgorithm) Bias() Bias {
+	return b.bias
+}
+
+// Generate implements the Algorithm interface using the Binary Tree algorithm.
+// Cells are processed in row order, so the start position is ignored.
+func (b *BinaryTreeAlgorithm) Generate(maze *Maze, startRow, startCol int, rng *rand.Rand) {
+	generateFromRows(b, maze, rng)
+}
+
+// GenerateRows implements the RowGenerator interface.
+// Each grid row is emitted as a freshly allocated slice, from top to bottom.
+func (b *BinaryTreeAlgorithm) GenerateRows(width, height int, rng *rand.Rand, emit func(row []bool) error) error {
+	cellCols := (width - 1) / 2
+	cellRows := (height - 1) / 2
+	rowStep, colStep := b.bias.rowStep(), b.bias.colStep()
+
+	// above is the grid row between the previous row of cells and the current one
+	above := newWallRow(width)
+	for r := 0; r < cellRows; r++ {
+		cellRow := newWallRow(width)
+		below := newWallRow(width)
+		canCarveVertical := r+rowStep >= 0 && r+rowStep < cellRows
+
+		for c := 0; c < cellCols; c++ {
+			cellRow[2*c+1] = false
+			canCarveHorizontal := c+colStep >= 0 && c+colStep < cellCols
+
+			carveVertical := canCarveVertical
+			if canCarveVertical && canCarveHorizontal {
+				carveVertical = rng.Intn(2) == 0
+			}
+
+			switch {
+			case carveVertical && rowStep < 0:
+				above[2*c+1] = false
+			case carveVertical:
+				below[2*c+1] = false
+			case canCarveHorizontal:
+				cellRow[2*c+1+colStep] = false
+			}
+		}
+
+		if err := emit(above); err != nil {
+			return err
+		}
+		if err := emit(cellRow); err != nil {
+			return err
+		}
+		above = below
+	}
+
+	// Bottom boundary
+	return emit(above)
+}
@@ -0,0 +1,79 @@
+package maze
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// cellDirections lists the moves between cells: up, right, down, left
+var cellDirections = [4]Position{{-2, 0}, {0, 2}, {2, 0}, {0, -2}}
+
+// Braid removes a fraction (0.0-1.0) of the maze's dead ends by knocking out
+// walls, which creates loops. Dead ends are visited in random order and each
+// one is joined to a neighboring cell, preferring a neighbor that is itself a
+// dead end so that one wall removes two of them. A fraction of 1.0 leaves no
+// dead ends at all. It works on the grid alone, so any algorithm can be braided.
+func Braid(maze *Maze, fraction float64, rng *rand.Rand) error {
+	if fraction < 0 || fraction > 1 || math.IsNaN(fraction) {
+		return fmt.Errorf("braid fraction must be between 0.0 and 1.0, got %v", fraction)
+	}
+
+	deadEnds := DeadEnds(maze)
+	rng.Shuffle(len(deadEnds), func(i, j int) {
+		deadEnds[i], deadEnds[j] = deadEnds[j], deadEnds[i]
+	})
+
+	count := int(math.Round(fraction * float64(len(deadEnds))))
+	for _, cell := range deadEnds[:count] {
+		if openPassages(maze, cell) != 1 {
+			continue // Already joined while braiding a neighbor
+		}
+
+		// Closed neighbors, with dead ends preferred
+		var candidates, deadEndCandidates []cellMove
+		for _, dir := range cellDirections {
+			neighbor, wall, ok := maze.step(cell.Row, cell.Col, dir.Row, dir.Col)
+			if !ok || !isPathCell(maze, neighbor) || !maze.Grid[wall.Row][wall.Col] {
+				continue
+			}
+			candidates = append(candidates, cellMove{cell: neighbor, wall: wall})
+			if openPassages(maze, neighbor) == 1 {
+				deadEndCandidates = append(deadEndCandidates, cellMove{cell: neighbor, wall: wall})
+			}
+		}
+		if len(deadEndCandidates) > 0 {
+			candidates = deadEndCandidates
+		}
+		if len(candidates) == 0 {
+			continue
+		}
+
+		neighbor := candidates[rng.Intn(len(candidates))]
+		maze.setWall(neighbor.wall, false)
+	}
+	return nil
+}
+
+// DeadEnds returns every open cell with exactly one open passage, in row-major order
+func DeadEnds(maze *Maze) []Position {
+	deadEnds := make([]Position, 0)
+	for _, cell := range pathCells(maze) {
+		if openPassages(maze, cell) == 1 {
+			deadEnds = append(deadEnds, cell)
+		}
+	}
+	return deadEnds
+}
+
+// openPassages counts the open grid positions next to a cell
+func openPassages(maze *Maze, cell Position) int {
+	count := 0
+	for _, dir := range cellDirections {
+		row, col := cell.Row+dir.Row/2, cell.Col+dir.Col/2
+		if row >= 0 && row < maze.Height && col >= 0 && col < maze.Width && !maze.Grid[row][col] {
+			count++
+		}
+	}
+	return count
+}
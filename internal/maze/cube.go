@@ -0,0 +1,287 @@
+package maze
+
+import "fmt"
+
+// CubeMaze is a maze that covers the six faces of a cube, each a square of
+// Size x Size cells, with passages crossing the edges where faces meet.
+// Cells are addressed by FacePosition. Each face is seen from outside the
+// cube, the way it appears in the cross-shaped net returned by Net:
+//
+//	    Top
+//	Left Front Right Back
+//	    Bottom
+//
+// The start is the top-left cell of the front face, and the goal the cell at
+// the opposite corner of the cube, on the back face.
+type CubeMaze struct {
+	layoutMaze[FacePosition]
+	Size int
+}
+
+// FacePosition is a cell on one face of a cube maze, in cell coordinates
+type FacePosition struct {
+	Face int
+	Row  int
+	Col  int
+}
+
+// Cube faces
+const (
+	CubeFront = iota
+	CubeRight
+	CubeBack
+	CubeLeft
+	CubeTop
+	CubeBottom
+	cubeFaces
+)
+
+// Cube neighbor slots, as seen on the face of the cell. A slot that crosses
+// an edge leads onto the neighboring face, where it may point another way.
+const (
+	CubeNorth = iota
+	CubeEast
+	CubeSouth
+	CubeWest
+	cubeSlots
+)
+
+// cubeVector is a point or direction in the cube's frame: x to the right, y
+// down and z towards the viewer, in half cells
+type cubeVector [3]int
+
+// cubeFrame places a face on the cube and in the net. The corner is the
+// top-left corner of the face in units of the cube's side, and right and down
+// run along its rows and columns.
+type cubeFrame struct {
+	corner, right, down cubeVector
+	net                 Position // Face row and column in the net
+}
+
+// cubeFrames folds the net into a cube around the front face
+var cubeFrames = [cubeFaces]cubeFrame{
+	CubeFront:  {cubeVector{0, 0, 1}, cubeVector{1, 0, 0}, cubeVector{0, 1, 0}, Position{Row: 1, Col: 1}},
+	CubeRight:  {cubeVector{1, 0, 1}, cubeVector{0, 0, -1}, cubeVector{0, 1, 0}, Position{Row: 1, Col: 2}},
+	CubeBack:   {cubeVector{1, 0, 0}, cubeVector{-1, 0, 0}, cubeVector{0, 1, 0}, Position{Row: 1, Col: 3}},
+	CubeLeft:   {cubeVector{0, 0, 0}, cubeVector{0, 0, 1}, cubeVector{0, 1, 0}, Position{Row: 1, Col: 0}},
+	CubeTop:    {cubeVector{0, 0, 0}, cubeVector{1, 0, 0}, cubeVector{0, 0, 1}, Position{Row: 0, Col: 1}},
+	CubeBottom: {cubeVector{0, 1, 1}, cubeVector{1, 0, 0}, cubeVector{0, 0, -1}, Position{Row: 2, Col: 1}},
+}
+
+// NewCubeMaze creates a cube maze of size x size cells per face with every
+// wall standing
+func NewCubeMaze(size int) *CubeMaze {
+	start, goal := FacePosition{Face: CubeFront, Row: 0, Col: 0}, FacePosition{Face: CubeBack, Row: size - 1, Col: 0}
+	return &CubeMaze{
+		layoutMaze: newLayoutMaze[FacePosition](cubeLayout{size: size}, start, goal),
+		Size:       size,
+	}
+}
+
+// GenerateCube creates a maze on the surface of a cube with size x size
+// cells per face. The configured algorithm must be a GraphAlgorithm.
+func (g *Generator) GenerateCube(size int) (*CubeMaze, error) {
+	if size < 2 {
+		return nil, fmt.Errorf("cube faces must be at least 2x2 cells, got %dx%d", size, size)
+	}
+
+	maze := NewCubeMaze(size)
+	if err := maze.generate(g, "cube mazes", maze.Start); err != nil {
+		return nil, err
+	}
+	return maze, nil
+}
+
+// Render draws the maze with a renderer that supports cube mazes, and
+// reports false if the renderer does not
+func (c *CubeMaze) Render(renderer Renderer) (string, bool) {
+	cubeRenderer, ok := renderer.(CubeRenderer)
+	if !ok {
+		return "", false
+	}
+	return cubeRenderer.RenderCube(c), true
+}
+
+// NetPosition returns the grid position of a cell in the maze returned by Net
+func (c *CubeMaze) NetPosition(pos FacePosition) Position {
+	net := cubeFrames[pos.Face].net
+	return Position{Row: 2*(net.Row*c.Size+pos.Row) + 1, Col: 2*(net.Col*c.Size+pos.Col) + 1}
+}
+
+// Net unfolds the cube into a cross-shaped net, returned as a masked square
+// maze that any renderer can draw. Faces that are side by side in the net
+// share the wall line between them. A passage across any other edge opens
+// the outline of the net on both faces, so the gaps meet when the net is cut
+// out and folded. The solution path, if any, jumps between those gaps.
+func (c *CubeMaze) Net() *Maze {
+	n := c.Size
+	net := &Maze{Width: 8*n + 1, Height: 6*n + 1, Mask: NewMask(3*n, 4*n)}
+	net.Grid = make([][]bool, net.Height)
+	for i := range net.Grid {
+		net.Grid[i] = make([]bool, net.Width)
+		for j := range net.Grid[i] {
+			net.Grid[i][j] = true
+		}
+	}
+	for row := 0; row < net.Mask.Rows; row++ {
+		for col := 0; col < net.Mask.Cols; col++ {
+			net.Mask.disabled[row][col] = true
+		}
+	}
+
+	for cell := 0; cell < c.layout.CellCount(); cell++ {
+		pos := c.NetPosition(c.layout.position(cell))
+		net.Grid[pos.Row][pos.Col] = false
+		net.Mask.disabled[(pos.Row-1)/2][(pos.Col-1)/2] = false
+		for slot := 0; slot < cubeSlots; slot++ {
+			if c.graph.linked(cell, slot) {
+				dir := cellDirections[slot]
+				net.Grid[pos.Row+dir.Row/2][pos.Col+dir.Col/2] = false
+			}
+		}
+	}
+
+	start, goal := c.NetPosition(c.Start), c.NetPosition(c.Goal)
+	net.StartRow, net.StartCol = start.Row, start.Col
+	net.GoalRow, net.GoalCol = goal.Row, goal.Col
+
+	for i, face := range c.SolutionPath {
+		pos := c.NetPosition(face)
+		if i > 0 {
+			previous := c.SolutionPath[i-1]
+			exit := c.netGap(previous, face)
+			net.SolutionPath = append(net.SolutionPath, exit)
+			if entry := c.netGap(face, previous); entry != exit {
+				net.SolutionPath = append(net.SolutionPath, entry)
+			}
+		}
+		net.SolutionPath = append(net.SolutionPath, pos)
+	}
+	return net
+}
+
+// netGap returns the wall position in the net that the passage from one
+// cell to its neighbor goes through, on the side of the first cell
+func (c *CubeMaze) netGap(from, to FacePosition) Position {
+	pos := c.NetPosition(from)
+	for slot, dir := range cellDirections {
+		if next, _ := c.Neighbor(from, slot); next == to {
+			return Position{Row: pos.Row + dir.Row/2, Col: pos.Col + dir.Col/2}
+		}
+	}
+	return pos
+}
+
+// cubeLayout is the cellLayout of a cube maze, numbered face by face in
+// row-major order. Neighbors are found by stepping between cell centers in
+// the cube's frame, and around the edge onto the next face where a step
+// leaves its own face.
+type cubeLayout struct {
+	size int
+}
+
+// CellCount implements the cellLayout interface
+func (l cubeLayout) CellCount() int {
+	return cubeFaces * l.size * l.size
+}
+
+// Degree implements the cellLayout interface: every cell has four slots
+func (l cubeLayout) Degree(int) int {
+	return cubeSlots
+}
+
+// Neighbor implements the cellLayout interface. Every slot has a neighbor,
+// since the surface of a cube has no border.
+func (l cubeLayout) Neighbor(cell, slot int) int {
+	pos := l.position(cell)
+	frame := cubeFrames[pos.Face]
+	dir := l.direction(frame, slot)
+	center := l.point(pos)
+
+	next := center.add(dir, 2)
+	if face, ok := l.locate(next); ok {
+		return l.cell(face)
+	}
+	// Round the edge: half a cell to it, then half a cell down the next face
+	next = center.add(dir, 1).add(frame.right.cross(frame.down), -1)
+	face, _ := l.locate(next)
+	return l.cell(face)
+}
+
+// reverse implements the cellLayout interface. Across an edge the faces are
+// turned against each other, so the slot back is looked up from the neighbor.
+func (l cubeLayout) reverse(cell, slot int) int {
+	next := l.Neighbor(cell, slot)
+	for back := 0; back < cubeSlots; back++ {
+		if l.Neighbor(next, back) == cell {
+			return back
+		}
+	}
+	return -1
+}
+
+// direction returns the step of the given slot on a face
+func (l cubeLayout) direction(frame cubeFrame, slot int) cubeVector {
+	switch slot {
+	case CubeNorth:
+		return frame.down.scale(-1)
+	case CubeEast:
+		return frame.right
+	case CubeSouth:
+		return frame.down
+	default:
+		return frame.right.scale(-1)
+	}
+}
+
+// point returns the center of a cell in the cube's frame
+func (l cubeLayout) point(pos FacePosition) cubeVector {
+	frame := cubeFrames[pos.Face]
+	return frame.corner.scale(2*l.size).add(frame.right, 2*pos.Col+1).add(frame.down, 2*pos.Row+1)
+}
+
+// locate finds the cell whose center is at p, if there is one
+func (l cubeLayout) locate(p cubeVector) (FacePosition, bool) {
+	for face, frame := range cubeFrames {
+		offset := p.add(frame.corner, -2*l.size)
+		if offset.dot(frame.right.cross(frame.down)) != 0 {
+			continue // Not in the plane of this face
+		}
+		x, y := offset.dot(frame.right), offset.dot(frame.down)
+		if x > 0 && x < 2*l.size && y > 0 && y < 2*l.size {
+			return FacePosition{Face: face, Row: (y - 1) / 2, Col: (x - 1) / 2}, true
+		}
+	}
+	return FacePosition{}, false
+}
+
+// cell numbers the cells face by face in row-major order
+func (l cubeLayout) cell(pos FacePosition) int {
+	return (pos.Face*l.size+pos.Row)*l.size + pos.Col
+}
+
+// position converts a cell number back to a face and cell coordinates
+func (l cubeLayout) position(cell int) FacePosition {
+	return FacePosition{Face: cell / (l.size * l.size), Row: cell / l.size % l.size, Col: cell % l.size}
+}
+
+// add returns v plus k times w
+func (v cubeVector) add(w cubeVector, k int) cubeVector {
+	return cubeVector{v[0] + k*w[0], v[1] + k*w[1], v[2] + k*w[2]}
+}
+
+// scale returns k times v
+func (v cubeVector) scale(k int) cubeVector {
+	return cubeVector{k * v[0], k * v[1], k * v[2]}
+}
+
+// dot returns the dot product of v and w
+func (v cubeVector) dot(w cubeVector) int {
+	return v[0]*w[0] + v[1]*w[1] + v[2]*w[2]
+}
+
+// cross returns the cross product of v and w. For a face, right x down is
+// the normal pointing out of the cube.
+func (v cubeVector) cross(w cubeVector) cubeVector {
+	return cubeVector{v[1]*w[2] - v[2]*w[1], v[2]*w[0] - v[0]*w[2], v[0]*w[1] - v[1]*w[0]}
+}
@@ -0,0 +1,102 @@
+package maze
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestCubeTopologyError(t *testing.T) {
+	generator := NewGeneratorWithSeed("1")
+	if _, err := generator.GenerateWithOptions(21, 21, GenerateOptions{Topology: TopologyCube}); err == nil {
+		t.Error("Expected an error for a square maze with the cube topology")
+	}
+}
+
+func TestCubeNeighbors(t *testing.T) {
+	maze := NewCubeMaze(3)
+	tests := []struct {
+		pos      FacePosition
+		slot     int
+		expected FacePosition
+	}{
+		{FacePosition{CubeFront, 1, 1}, CubeEast, FacePosition{CubeFront, 1, 2}},
+		{FacePosition{CubeTop, 2, 1}, CubeSouth, FacePosition{CubeFront, 0, 1}},
+		{FacePosition{CubeTop, 0, 0}, CubeNorth, FacePosition{CubeBack, 0, 2}},
+		{FacePosition{CubeTop, 1, 2}, CubeEast, FacePosition{CubeRight, 0, 1}},
+		{FacePosition{CubeRight, 1, 2}, CubeEast, FacePosition{CubeBack, 1, 0}},
+		{FacePosition{CubeLeft, 1, 0}, CubeWest, FacePosition{CubeBack, 1, 2}},
+		{FacePosition{CubeBottom, 2, 0}, CubeSouth, FacePosition{CubeBack, 2, 2}},
+		{FacePosition{CubeBottom, 0, 0}, CubeWest, FacePosition{CubeLeft, 2, 2}},
+	}
+	for _, tt := range tests {
+		if next, ok := maze.Neighbor(tt.pos, tt.slot); !ok || next != tt.expected {
+			t.Errorf("Neighbor(%v, %d) = %v, %v; expected %v", tt.pos, tt.slot, next, ok, tt.expected)
+		}
+	}
+
+	// Round the four edges of a face and back, ending where it started
+	pos := FacePosition{CubeTop, 0, 0}
+	for i := 0; i < 4*maze.Size; i++ {
+		pos, _ = maze.Neighbor(pos, CubeNorth)
+	}
+	if pos != (FacePosition{CubeTop, 0, 0}) {
+		t.Errorf("Expected to come back to the start after going round the cube, got %v", pos)
+	}
+}
+
+// createNetMaze returns a cube maze of one cell per face, with a passage
+// from the front face round the right face to the back face, and one over
+// the cut edge between the top and back faces
+func createNetMaze() *CubeMaze {
+	maze := NewCubeMaze(1)
+	maze.link(FacePosition{Face: CubeFront}, CubeEast)
+	maze.link(FacePosition{Face: CubeRight}, CubeEast)
+	maze.link(FacePosition{Face: CubeTop}, CubeNorth)
+	return maze
+}
+
+func TestCubeNet(t *testing.T) {
+	maze := createNetMaze()
+	maze.Solve()
+	if len(maze.SolutionPath) != 3 {
+		t.Fatalf("Expected a solution of three cells, got %v", maze.SolutionPath)
+	}
+
+	lines := strings.Split((&ASCIIRenderer{}).RenderCube(maze), "\n")
+	expected := map[int]string{
+		0: "  # #    ", // Gap in the top face where the passage leaves for the back face
+		2: "####### #", // and the matching gap in the back face
+		3: "# #●···○#",
+		6: "  ###    ",
+	}
+	for i, line := range expected {
+		if lines[i] != line {
+			t.Errorf("Expected net line %d to be %q, got %q", i, line, lines[i])
+		}
+	}
+
+	net := maze.Net()
+	if net.Width != 9 || net.Height != 7 || !net.outsideMask(1, 7) || net.outsideMask(1, 3) {
+		t.Errorf("Expected a 9x7 net masked outside the cross, got %dx%d", net.Width, net.Height)
+	}
+}
+
+func TestRenderCube(t *testing.T) {
+	maze := createNetMaze()
+
+	svg := (&SVGRenderer{}).RenderCube(maze)
+	checkSVG(t, svg)
+	if folds := strings.Count(svg, "stroke-dasharray"); folds != 5 {
+		t.Errorf("Expected 5 fold lines, got %d", folds)
+	}
+
+	var parsed CubeJSON
+	if err := json.Unmarshal([]byte((&JSONRenderer{}).RenderCube(maze)), &parsed); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	expected := CubePassage{From: FacePosition{Face: CubeBack}, To: FacePosition{Face: CubeTop}}
+	if parsed.Shape != "cube" || parsed.Size != 1 || len(parsed.Passages) != 3 || parsed.Passages[2] != expected {
+		t.Errorf("Unexpected JSON output: %+v", parsed)
+	}
+}
@@ -0,0 +1,125 @@
+package maze
+
+import "fmt"
+
+// DeltaMaze is a maze of triangular cells. Each row alternates between
+// triangles pointing up and down, starting with one pointing up, and the
+// triangles of the next row are flipped, so cell (row, col) points up when
+// row+col is even. Cells are addressed by Position in cell coordinates.
+type DeltaMaze struct {
+	layoutMaze[Position]
+	Rows int
+	Cols int
+}
+
+// Delta neighbor slots. DeltaBase is the neighbor across the horizontal
+// side: below a triangle that points up, above one that points down.
+const (
+	DeltaWest = iota
+	DeltaEast
+	DeltaBase
+	deltaSlots
+)
+
+// NewDeltaMaze creates a triangular maze with every wall standing, and the
+// start and goal in the top-left and bottom-right cells
+func NewDeltaMaze(rows, cols int) *DeltaMaze {
+	start, goal := Position{Row: 0, Col: 0}, Position{Row: rows - 1, Col: cols - 1}
+	return &DeltaMaze{
+		layoutMaze: newLayoutMaze[Position](deltaLayout{rows: rows, cols: cols}, start, goal),
+		Rows:       rows,
+		Cols:       cols,
+	}
+}
+
+// GenerateDelta creates a triangular maze of rows x cols cells. The
+// configured algorithm must be a GraphAlgorithm.
+func (g *Generator) GenerateDelta(rows, cols int) (*DeltaMaze, error) {
+	if rows < 2 || cols < 2 {
+		return nil, fmt.Errorf("triangular maze must be at least 2x2 cells, got %dx%d", cols, rows)
+	}
+
+	maze := NewDeltaMaze(rows, cols)
+	if err := maze.generate(g, "triangular mazes", maze.Start); err != nil {
+		return nil, err
+	}
+	return maze, nil
+}
+
+// PointsUp reports whether the triangle at pos points up
+func (d *DeltaMaze) PointsUp(pos Position) bool {
+	return (pos.Row+pos.Col)%2 == 0
+}
+
+// Render draws the maze with a renderer that supports triangular mazes, and
+// reports false if the renderer does not
+func (d *DeltaMaze) Render(renderer Renderer) (string, bool) {
+	deltaRenderer, ok := renderer.(DeltaRenderer)
+	if !ok {
+		return "", false
+	}
+	return deltaRenderer.RenderDelta(d), true
+}
+
+// deltaLayout is the cellLayout of a triangular grid, numbered in row-major order
+type deltaLayout struct {
+	rows, cols int
+}
+
+// CellCount implements the cellLayout interface
+func (l deltaLayout) CellCount() int {
+	return l.rows * l.cols
+}
+
+// Degree implements the cellLayout interface: every cell has three slots
+func (l deltaLayout) Degree(int) int {
+	return deltaSlots
+}
+
+// Neighbor implements the cellLayout interface
+func (l deltaLayout) Neighbor(cell, slot int) int {
+	next, ok := l.neighbor(l.position(cell), slot)
+	if !ok {
+		return -1
+	}
+	return l.cell(next)
+}
+
+// reverse implements the cellLayout interface: west and east face each
+// other, and the neighbor across the base shares it as its own base
+func (l deltaLayout) reverse(_, slot int) int {
+	switch slot {
+	case DeltaWest:
+		return DeltaEast
+	case DeltaEast:
+		return DeltaWest
+	default:
+		return DeltaBase
+	}
+}
+
+// neighbor returns the cell next to pos in the given slot, if it is inside the grid
+func (l deltaLayout) neighbor(pos Position, slot int) (Position, bool) {
+	next := pos
+	switch {
+	case slot == DeltaWest:
+		next.Col--
+	case slot == DeltaEast:
+		next.Col++
+	case (pos.Row+pos.Col)%2 == 0:
+		next.Row++ // Below the base of a triangle pointing up
+	default:
+		next.Row--
+	}
+	return next, next.Row >= 0 && next.Row < l.rows && next.Col >= 0 && next.Col < l.cols
+}
+
+// cell numbers the cells in row-major order
+func (l deltaLayout) cell(pos Position) int {
+	return pos.Row*l.cols + pos.Col
+}
+
+// position converts a cell number back to cell coordinates
+func (l deltaLayout) position(cell int) Position {
+	return Position{Row: cell / l.cols, Col: cell % l.cols}
+}
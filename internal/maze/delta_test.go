@@ -0,0 +1,66 @@
+package maze
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestDeltaNeighbors(t *testing.T) {
+	maze := NewDeltaMaze(3, 3)
+	tests := []struct {
+		pos      Position
+		up       bool
+		expected [deltaSlots]Position
+	}{
+		// Pointing up: the base is shared with the triangle below
+		{Position{1, 1}, true, [deltaSlots]Position{{1, 0}, {1, 2}, {2, 1}}},
+		// Pointing down: the base is shared with the triangle above
+		{Position{1, 0}, false, [deltaSlots]Position{{1, -1}, {1, 1}, {0, 0}}},
+	}
+	for _, tt := range tests {
+		if maze.PointsUp(tt.pos) != tt.up {
+			t.Errorf("Expected PointsUp(%v) to be %v", tt.pos, tt.up)
+		}
+		for slot, expected := range tt.expected {
+			next, ok := maze.Neighbor(tt.pos, slot)
+			inside := expected.Col >= 0
+			if ok != inside || inside && next != expected {
+				t.Errorf("Neighbor(%v, %d) = %v, %v; expected %v, %v", tt.pos, slot, next, ok, expected, inside)
+			}
+		}
+	}
+}
+
+func TestRenderDelta(t *testing.T) {
+	// A triangle pointing up and one pointing down, joined through their shared side
+	maze := NewDeltaMaze(1, 2)
+	maze.link(Position{Row: 0, Col: 0}, DeltaEast)
+	maze.Solve()
+	if len(maze.SolutionPath) != 2 {
+		t.Fatalf("Expected a solution of two cells, got %v", maze.SolutionPath)
+	}
+
+	svg := (&SVGRenderer{}).RenderDelta(maze)
+	walls := checkSVG(t, svg)
+	if segments := strings.Count(walls, "M"); segments != 4 {
+		t.Errorf("Expected 4 wall segments, got %d: %s", segments, walls)
+	}
+	if !strings.Contains(svg, `width="56" height="40.78"`) {
+		t.Errorf("Expected a 56x40.78 drawing, got:\n%s", svg)
+	}
+
+	var parsed DeltaJSON
+	if err := json.Unmarshal([]byte((&JSONRenderer{}).RenderDelta(maze)), &parsed); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	expected := Passage{From: Position{0, 0}, To: Position{0, 1}}
+	if parsed.Shape != "delta" || len(parsed.Passages) != 1 || parsed.Passages[0] != expected || len(parsed.SolutionPath) != 2 {
+		t.Errorf("Unexpected JSON output: %+v", parsed)
+	}
+
+	renderer, _ := NewRenderer("unicode")
+	if _, ok := maze.Render(renderer); ok {
+		t.Error("Expected Unicode output not to support triangular mazes")
+	}
+}
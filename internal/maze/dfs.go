@@ -5,49 +5,73 @@ import "math/rand"
 // DFSAlgorithm implements maze generation using Depth-First Search
 type DFSAlgorithm struct{}
 
+// dfsFrame is one entry of the explicit DFS stack. It is kept small because a
+// single corridor can put millions of cells on the stack at once; the shuffled
+// slot order of each frame lives on a separate stack of degree bytes.
+type dfsFrame struct {
+	cell   int32
+	degree uint8 // number of neighbor slots, and of entries on the order stack
+	next   uint8 // number of slots already tried
+}
+
 // Generate implements the Algorithm interface using DFS
 func (d *DFSAlgorithm) Generate(maze *Maze, startRow, startCol int, rng *rand.Rand) {
-	d.generateDFS(maze, startRow, startCol, rng)
+	generateOnGrid(d, maze, startRow, startCol, rng)
 }
 
-// generateDFS implements Depth-First Search maze generation algorithm
-func (d *DFSAlgorithm) generateDFS(maze *Maze, startRow, startCol int, rng *rand.Rand) {
-	// Mark starting cell as path
-	maze.Grid[startRow][startCol] = false
-
-	// Define directions: up, right, down, left
-	directions := [][2]int{{-2, 0}, {0, 2}, {2, 0}, {0, -2}}
+// GenerateGraph implements the GraphAlgorithm interface using Depth-First Search.
+// It uses an explicit stack instead of recursion so that huge mazes do not
+// need one goroutine stack frame per carved cell, while consuming random
+// numbers in exactly the same order as the classic recursive backtracker.
+func (d *DFSAlgorithm) GenerateGraph(graph Graph, start int, rng *rand.Rand) {
+	visited := make([]bool, graph.CellCount())
+	var orders []uint8
+	stack := []dfsFrame{d.visit(graph, start, visited, &orders, rng)}
 
-	// Shuffle directions for randomness
-	d.shuffleDirections(directions, rng)
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		if top.next == top.degree {
+			// All slots tried: backtrack
+			orders = orders[:len(orders)-int(top.degree)]
+			stack = stack[:len(stack)-1]
+			continue
+		}
 
-	// Try each direction
-	for _, dir := range directions {
-		newRow := startRow + dir[0]
-		newCol := startCol + dir[1]
+		slot := int(orders[len(orders)-int(top.degree)+int(top.next)])
+		top.next++
 
-		// Check if new position is valid and unvisited
-		if d.isValidCell(maze, newRow, newCol) && maze.Grid[newRow][newCol] {
+		// Check if the neighbor exists and is unvisited
+		if next := graph.Neighbor(int(top.cell), slot); next >= 0 && !visited[next] {
 			// Remove wall between current and new cell
-			wallRow := startRow + dir[0]/2
-			wallCol := startCol + dir[1]/2
-			maze.Grid[wallRow][wallCol] = false
+			graph.Link(int(top.cell), slot)
 
-			// Recursively generate from new cell
-			d.generateDFS(maze, newRow, newCol, rng)
+			// Continue from the new cell
+			stack = append(stack, d.visit(graph, next, visited, &orders, rng))
 		}
 	}
 }
 
-// shuffleDirections randomizes the order of directions
-func (d *DFSAlgorithm) shuffleDirections(directions [][2]int, rng *rand.Rand) {
-	for i := len(directions) - 1; i > 0; i-- {
-		j := rng.Intn(i + 1)
-		directions[i], directions[j] = directions[j], directions[i]
+// visit marks a cell as visited, pushes its shuffled slots onto the order
+// stack and returns its stack frame
+func (d *DFSAlgorithm) visit(graph Graph, cell int, visited []bool, orders *[]uint8, rng *rand.Rand) dfsFrame {
+	visited[cell] = true
+
+	degree := graph.Degree(cell)
+	for slot := 0; slot < degree; slot++ {
+		*orders = append(*orders, uint8(slot)) // #nosec G115 - degrees fit in a byte
+	}
+	d.shuffleSlots((*orders)[len(*orders)-degree:], rng)
+
+	return dfsFrame{
+		cell:   int32(cell),   // #nosec G115 - cell counts fit in int32
+		degree: uint8(degree), // #nosec G115 - degrees fit in a byte
 	}
 }
 
-// isValidCell checks if a cell position is within bounds
-func (d *DFSAlgorithm) isValidCell(maze *Maze, row, col int) bool {
-	return row > 0 && row < maze.Height-1 && col > 0 && col < maze.Width-1
+// shuffleSlots randomizes the order of neighbor slots
+func (d *DFSAlgorithm) shuffleSlots(slots []uint8, rng *rand.Rand) {
+	for i := len(slots) - 1; i > 0; i-- {
+		j := rng.Intn(i + 1)
+		slots[i], slots[j] = slots[j], slots[i]
+	}
 }
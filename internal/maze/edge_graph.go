@@ -0,0 +1,124 @@
+package maze
+
+import "fmt"
+
+// EdgeGraph is a Graph given as a list of undirected edges between nodes
+// numbered from 0, such as a graph supplied by the user. The slots of a node
+// are its edges in the order they are listed. Generating a maze over it opens
+// the edges of a spanning tree.
+type EdgeGraph struct {
+	edges   [][2]int
+	weights []float64 // Weight of each edge, 1 unless set
+	slots   [][]int32 // Edge behind each slot of each node
+	open    []bool    // Whether each edge has been linked
+}
+
+// NewEdgeGraph creates a graph of nodeCount nodes joined by the given edges.
+// The graph must be connected, so that it has a spanning tree, and every
+// node must have at most MaxGraphDegree edges. Edges from a node to itself
+// and repeated edges are rejected. Every edge starts with a weight of 1.
+func NewEdgeGraph(nodeCount int, edges [][2]int) (*EdgeGraph, error) {
+	return newEdgeGraph(nodeCount, edges, func(node int) string { return fmt.Sprintf("node %d", node) })
+}
+
+// newEdgeGraph is NewEdgeGraph with the nodes called by the given names in
+// its errors
+func newEdgeGraph(nodeCount int, edges [][2]int, name func(node int) string) (*EdgeGraph, error) {
+	if nodeCount < 1 {
+		return nil, fmt.Errorf("graph must have at least one node")
+	}
+
+	g := &EdgeGraph{edges: edges, weights: make([]float64, len(edges)), slots: make([][]int32, nodeCount), open: make([]bool, len(edges))}
+	seen := make(map[[2]int]bool, len(edges))
+	sets := NewUnionFind(nodeCount)
+	components := nodeCount
+	for i, edge := range edges {
+		from, to := edge[0], edge[1]
+		if from < 0 || from >= nodeCount || to < 0 || to >= nodeCount {
+			return nil, fmt.Errorf("edge %d joins nodes %d and %d, but there are only %d nodes", i, from, to, nodeCount)
+		}
+		if from == to {
+			return nil, fmt.Errorf("edge %d joins %s to itself", i, name(from))
+		}
+		key := [2]int{min(from, to), max(from, to)}
+		if seen[key] {
+			return nil, fmt.Errorf("edge %d joins %s and %s again", i, name(from), name(to))
+		}
+		seen[key] = true
+
+		for _, node := range edge {
+			if len(g.slots[node]) == MaxGraphDegree {
+				return nil, fmt.Errorf("%s has more than %d edges", name(node), MaxGraphDegree)
+			}
+			g.slots[node] = append(g.slots[node], int32(i)) // #nosec G115 - edge counts fit in int32
+		}
+		g.weights[i] = 1
+		if sets.Union(from, to) {
+			components--
+		}
+	}
+	if components > 1 {
+		return nil, fmt.Errorf("graph is not connected: it falls apart into %d pieces", components)
+	}
+	return g, nil
+}
+
+// CellCount implements the Graph interface
+func (g *EdgeGraph) CellCount() int {
+	return len(g.slots)
+}
+
+// Degree implements the Graph interface
+func (g *EdgeGraph) Degree(cell int) int {
+	return len(g.slots[cell])
+}
+
+// Neighbor implements the Graph interface
+func (g *EdgeGraph) Neighbor(cell, slot int) int {
+	edge := g.edges[g.slots[cell][slot]]
+	if edge[0] == cell {
+		return edge[1]
+	}
+	return edge[0]
+}
+
+// Link implements the Graph interface by opening the edge behind the slot
+func (g *EdgeGraph) Link(cell, slot int) {
+	g.open[g.slots[cell][slot]] = true
+}
+
+// Weight implements the WeightedGraph interface
+func (g *EdgeGraph) Weight(cell, slot int) float64 {
+	return g.weights[g.slots[cell][slot]]
+}
+
+// SetWeight sets the weight of the edge with the given index
+func (g *EdgeGraph) SetWeight(edge int, weight float64) {
+	g.weights[edge] = weight
+}
+
+// Edge returns the nodes joined by the edge with the given index
+func (g *EdgeGraph) Edge(edge int) (from, to int) {
+	return g.edges[edge][0], g.edges[edge][1]
+}
+
+// EdgeCount returns the number of edges
+func (g *EdgeGraph) EdgeCount() int {
+	return len(g.edges)
+}
+
+// Open reports whether the edge with the given index has been opened as a passage
+func (g *EdgeGraph) Open(edge int) bool {
+	return g.open[edge]
+}
+
+// Passages returns the indices of the open edges in the order they are listed
+func (g *EdgeGraph) Passages() []int {
+	passages := make([]int, 0, len(g.slots)-1)
+	for edge, open := range g.open {
+		if open {
+			passages = append(passages, edge)
+		}
+	}
+	return passages
+}
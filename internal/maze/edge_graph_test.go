@@ -0,0 +1,154 @@
+package maze
+
+import (
+	"strings"
+	"testing"
+)
+
+// petersenEdges are the edges of the Petersen graph: an outer pentagon, an
+// inner pentagram and five spokes between them
+var petersenEdges = [][2]int{
+	{0, 1}, {1, 2}, {2, 3}, {3, 4}, {4, 0},
+	{5, 7}, {7, 9}, {9, 6}, {6, 8}, {8, 5},
+	{0, 5}, {1, 6}, {2, 7}, {3, 8}, {4, 9},
+}
+
+// checkSpanningTree verifies that the open edges of a graph join all of its
+// nodes without a loop
+func checkSpanningTree(t *testing.T, graph *EdgeGraph) {
+	t.Helper()
+
+	passages := graph.Passages()
+	if len(passages) != graph.CellCount()-1 {
+		t.Fatalf("Expected %d passages for %d nodes, got %d", graph.CellCount()-1, graph.CellCount(), len(passages))
+	}
+	sets := NewUnionFind(graph.CellCount())
+	for _, edge := range passages {
+		if from, to := graph.Edge(edge); !sets.Union(from, to) {
+			t.Fatalf("Passage %d between nodes %d and %d closes a loop", edge, from, to)
+		}
+	}
+}
+
+func TestEdgeGraphMazes(t *testing.T) {
+	complete := [][2]int{}
+	for i := 0; i < 6; i++ {
+		for j := i + 1; j < 6; j++ {
+			complete = append(complete, [2]int{i, j})
+		}
+	}
+	star := [][2]int{}
+	for i := 1; i <= MaxGraphDegree; i++ {
+		star = append(star, [2]int{0, i})
+	}
+	graphs := []struct {
+		name  string
+		nodes int
+		edges [][2]int
+	}{
+		{"petersen", 10, petersenEdges},
+		{"complete", 6, complete},
+		{"star", MaxGraphDegree + 1, star},
+		{"single", 1, nil},
+	}
+
+	for _, name := range GetSupportedAlgorithms() {
+		t.Run(name, func(t *testing.T) {
+			generator, _ := NewGeneratorWithSeedAndAlgorithm("1", name)
+			if _, ok := generator.algorithm.(GraphAlgorithm); !ok {
+				graph, _ := NewEdgeGraph(10, petersenEdges)
+				want := "algorithm " + name + " does not support arbitrary graphs (supported: "
+				if err := generator.GenerateGraph(graph, 0); err == nil || !strings.Contains(err.Error(), want) {
+					t.Errorf("Expected an error containing %q, got %v", want, err)
+				}
+				return
+			}
+
+			for _, tt := range graphs {
+				for _, seed := range []string{"1", "2", "3"} {
+					generator, _ := NewGeneratorWithSeedAndAlgorithm(seed, name)
+					graph, err := NewEdgeGraph(tt.nodes, tt.edges)
+					if err != nil {
+						t.Fatalf("%s: unexpected error: %v", tt.name, err)
+					}
+					if err := generator.GenerateGraph(graph, tt.nodes-1); err != nil {
+						t.Fatalf("%s: unexpected error: %v", tt.name, err)
+					}
+					checkSpanningTree(t, graph)
+
+					again, _ := NewGeneratorWithSeedAndAlgorithm(seed, name)
+					other, _ := NewEdgeGraph(tt.nodes, tt.edges)
+					_ = again.GenerateGraph(other, tt.nodes-1)
+					if len(other.Passages()) != len(graph.Passages()) {
+						t.Fatalf("%s: expected the same seed to open the same edges", tt.name)
+					}
+					for i, edge := range graph.Passages() {
+						if other.Passages()[i] != edge {
+							t.Fatalf("%s: expected the same seed to open the same edges", tt.name)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestEdgeGraph(t *testing.T) {
+	graph, err := NewEdgeGraph(3, [][2]int{{0, 1}, {2, 1}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if graph.CellCount() != 3 || graph.EdgeCount() != 2 || graph.Degree(1) != 2 {
+		t.Errorf("Expected 3 nodes and 2 edges meeting at node 1, got %d nodes, %d edges and degree %d",
+			graph.CellCount(), graph.EdgeCount(), graph.Degree(1))
+	}
+	// Slots follow the order of the edges, whichever end the node is on
+	if graph.Neighbor(1, 0) != 0 || graph.Neighbor(1, 1) != 2 || graph.Neighbor(2, 0) != 1 {
+		t.Error("Expected node 1 to lead to nodes 0 and 2, and node 2 back to node 1")
+	}
+	graph.Link(2, 0)
+	if !graph.Open(1) || graph.Open(0) {
+		t.Error("Expected linking node 2 to open the second edge only")
+	}
+
+	generator := NewGeneratorWithSeed("1")
+	if err := generator.GenerateGraph(graph, 3); err == nil {
+		t.Error("Expected an error for a start outside the graph")
+	}
+	wide := wideGraph{graph}
+	if err := generator.GenerateGraph(wide, 0); err == nil || err.Error() != "cell 0 has 256 neighbor slots, more than 255" {
+		t.Errorf("Expected an error for a cell with too many slots, got %v", err)
+	}
+
+	star := [][2]int{}
+	for i := 1; i <= MaxGraphDegree+1; i++ {
+		star = append(star, [2]int{0, i})
+	}
+	tests := []struct {
+		nodes  int
+		edges  [][2]int
+		errMsg string
+	}{
+		{0, nil, "at least one node"},
+		{3, [][2]int{{0, 1}, {1, 3}}, "only 3 nodes"},
+		{3, [][2]int{{0, 1}, {1, 1}}, "edge 1 joins node 1 to itself"},
+		{3, [][2]int{{0, 1}, {1, 2}, {1, 0}}, "edge 2 joins node 1 and node 0 again"},
+		{4, [][2]int{{0, 1}, {2, 3}}, "not connected"},
+		{MaxGraphDegree + 2, star, "node 0 has more than 255 edges"},
+	}
+	for _, tt := range tests {
+		_, err := NewEdgeGraph(tt.nodes, tt.edges)
+		if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+			t.Errorf("%d nodes, %v: expected error containing %q, got %v", tt.nodes, tt.edges, tt.errMsg, err)
+		}
+	}
+}
+
+// wideGraph claims more neighbor slots per cell than a Graph may have
+type wideGraph struct {
+	*EdgeGraph
+}
+
+func (g wideGraph) Degree(int) int {
+	return MaxGraphDegree + 1
+}
@@ -0,0 +1,117 @@
+package maze
+
+import "math/rand"
+
+// EllerAlgorithm implements maze generation using Eller's algorithm.
+// It builds the maze one row of cells at a time and only remembers which set
+// each cell of the current row belongs to, so it can stream arbitrarily tall mazes.
+type EllerAlgorithm struct{}
+
+// Generate implements the Algorithm interface using Eller's algorithm.
+// Eller's algorithm always works top to bottom, so the start position is ignored.
+func (e *EllerAlgorithm) Generate(maze *Maze, startRow, startCol int, rng *rand.Rand) {
+	generateFromRows(e, maze, rng)
+}
+
+// GenerateRows implements the RowGenerator interface.
+// Each grid row is emitted as a freshly allocated slice, from top to bottom.
+func (e *EllerAlgorithm) GenerateRows(width, height int, rng *rand.Rand, emit func(row []bool) error) error {
+	cellCols := (width - 1) / 2
+	cellRows := (height - 1) / 2
+
+	// sets[c] is the set of the cell in column c of the current row, 0 = unassigned
+	sets := make([]int, cellCols)
+	nextSet := 1
+
+	// Top boundary
+	if err := emit(newWallRow(width)); err != nil {
+		return err
+	}
+
+	for r := 0; r < cellRows; r++ {
+		lastRow := r == cellRows-1
+
+		// Cells without a passage from above start in their own set
+		for c := range sets {
+			if sets[c] == 0 {
+				sets[c] = nextSet
+				nextSet++
+			}
+		}
+
+		// Randomly join adjacent cells of different sets; the last row joins all of them
+		cellRow := newWallRow(width)
+		for c := 0; c < cellCols; c++ {
+			cellRow[2*c+1] = false
+			if c == cellCols-1 || sets[c] == sets[c+1] {
+				continue
+			}
+			if lastRow || rng.Intn(2) == 0 {
+				cellRow[2*c+2] = false
+				e.mergeSets(sets, sets[c+1], sets[c])
+			}
+		}
+		if err := emit(cellRow); err != nil {
+			return err
+		}
+
+		if lastRow {
+			break
+		}
+
+		// Carve at least one passage down from every set
+		below := e.carveDown(sets, width, rng)
+		if err := emit(below); err != nil {
+			return err
+		}
+		for c := range sets {
+			if below[2*c+1] {
+				sets[c] = 0
+			}
+		}
+	}
+
+	// Bottom boundary
+	return emit(newWallRow(width))
+}
+
+// carveDown decides the vertical passages below the current row and returns the
+// grid row between this row of cells and the next one
+func (e *EllerAlgorithm) carveDown(sets []int, width int, rng *rand.Rand) []bool {
+	below := newWallRow(width)
+
+	// Group columns by set, keeping the sets in order of first appearance
+	order := make([]int, 0, len(sets))
+	members := make(map[int][]int)
+	for c, set := range sets {
+		if _, seen := members[set]; !seen {
+			order = append(order, set)
+		}
+		members[set] = append(members[set], c)
+	}
+
+	for _, set := range order {
+		cols := members[set]
+		carved := false
+		for _, c := range cols {
+			if rng.Intn(2) == 0 {
+				below[2*c+1] = false
+				carved = true
+			}
+		}
+		if !carved {
+			c := cols[rng.Intn(len(cols))]
+			below[2*c+1] = false
+		}
+	}
+	return below
+}
+
+// mergeSets moves every cell of set from into set to
+func (e *EllerAlgorithm) mergeSets(sets []int, from, to int) {
+	for c := range sets {
+		if sets[c] == from {
+			sets[c] = to
+		}
+	}
+}
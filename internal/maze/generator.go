@@ -4,8 +4,12 @@
 package maze
 
 import (
+	"bufio"
+	"fmt"
+	"io"
 	"math/rand"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -18,33 +22,81 @@ type Maze struct {
 	StartCol     int
 	GoalRow      int
 	GoalCol      int
-	SolutionPath []Position // Optional solution path from start to goal
+	SolutionPath []Position            // Optional solution path from start to goal
+	Openings     []Position            // Gaps carved into the border, if any
+	Mask         *Mask                 // Optional mask of the cells that are part of the maze
+	Rooms        []Room                // Rooms reserved with Generator.AddRoom, if any
+	Topology     Topology              // Which edges wrap around to the opposite edge
+	Crossings    map[Position]Crossing // Cells where one corridor passes over another, in weave mazes
+
+	layout *Mask // Cells open to the algorithm while rooms and obstacles are laid out
+}
+
+// GenerateOptions holds optional settings for Generator.GenerateWithOptions.
+// The zero value generates exactly the same maze as Generator.Generate.
+type GenerateOptions struct {
+	// Mask restricts the maze to the enabled cells. Its grid size must match
+	// the requested width and height (see Mask.GridWidth and Mask.GridHeight).
+	Mask *Mask
+	// Topology lets passages wrap around from one edge to the opposite edge
+	Topology Topology
+	// Braid is the fraction (0.0-1.0) of dead ends to remove, creating loops
+	Braid float64
+	// Start and Goal choose where the start and goal markers are placed.
+	// They never change the carved passages, only the marked cells.
+	Start Placement
+	Goal  Placement
+	// LongestPath places the start and goal at the two ends of the maze's
+	// longest path instead; it cannot be combined with Start or Goal.
+	LongestPath bool
+	// Entrance and Exit carve openings into the border on the chosen sides,
+	// in line with the start and goal, which then move into the openings.
+	Entrance Side
+	Exit     Side
+	// Sparse is the number of passes that fill in dead ends, applied last so
+	// that the route between the final start and goal is kept
+	Sparse int
+	// Weave is the chance (0.0-1.0) that each cell away from the border
+	// becomes a crossing, where one corridor passes under another. It needs
+	// a GraphAlgorithm and cannot be combined with masks, rooms, obstacles,
+	// wrap-around topologies or Sparse.
+	Weave float64
 }
 
 // Generator creates mazes using configurable algorithms and seeds.
 type Generator struct {
-	rand      *rand.Rand
-	algorithm Algorithm
+	rand          *rand.Rand
+	algorithm     Algorithm
+	algorithmName string           // Name of the algorithm, for error messages
+	rooms         []roomConstraint // Added with AddRoom
+	obstacles     []Rect           // Added with AddObstacle
 }
 
 // NewGenerator creates a new Generator with default DFS algorithm and random seed.
 func NewGenerator() *Generator {
 	algorithm, _ := NewAlgorithm("dfs") // Default to DFS algorithm
 	return &Generator{
-		rand:      rand.New(rand.NewSource(time.Now().UnixNano())), // #nosec G404 - not for cryptographic use
-		algorithm: algorithm,
+		rand:          rand.New(rand.NewSource(time.Now().UnixNano())), // #nosec G404 - not for cryptographic use
+		algorithm:     algorithm,
+		algorithmName: "dfs",
 	}
 }
 
 // NewGeneratorWithAlgorithm creates a Generator with a specific algorithm
 func NewGeneratorWithAlgorithm(algorithmName string) (*Generator, error) {
-	algorithm, err := NewAlgorithm(algorithmName)
+	return NewGeneratorWithAlgorithmOptions(algorithmName, AlgorithmOptions{})
+}
+
+// NewGeneratorWithAlgorithmOptions creates a Generator with a specific algorithm and tuning options
+func NewGeneratorWithAlgorithmOptions(algorithmName string, options AlgorithmOptions) (*Generator, error) {
+	algorithm, err := NewAlgorithmWithOptions(algorithmName, options)
 	if err != nil {
 		return nil, err
 	}
 	return &Generator{
-		rand:      rand.New(rand.NewSource(time.Now().UnixNano())), // #nosec G404 - not for cryptographic use
-		algorithm: algorithm,
+		rand:          rand.New(rand.NewSource(time.Now().UnixNano())), // #nosec G404 - not for cryptographic use
+		algorithm:     algorithm,
+		algorithmName: algorithmName,
 	}, nil
 }
 
@@ -59,13 +111,20 @@ func NewGeneratorWithSeed(seedStr string) *Generator {
 
 	algorithm, _ := NewAlgorithm("dfs") // Default to DFS algorithm
 	return &Generator{
-		rand:      rand.New(rand.NewSource(seed)), // #nosec G404 - not for cryptographic use
-		algorithm: algorithm,
+		rand:          rand.New(rand.NewSource(seed)), // #nosec G404 - not for cryptographic use
+		algorithm:     algorithm,
+		algorithmName: "dfs",
 	}
 }
 
 // NewGeneratorWithSeedAndAlgorithm creates a Generator with specific seed and algorithm
 func NewGeneratorWithSeedAndAlgorithm(seedStr, algorithmName string) (*Generator, error) {
+	return NewGeneratorWithSeedAndAlgorithmOptions(seedStr, algorithmName, AlgorithmOptions{})
+}
+
+// NewGeneratorWithSeedAndAlgorithmOptions creates a Generator with specific seed, algorithm and tuning options.
+// The options take part in reproducibility: the same seed, algorithm and options always produce the same maze.
+func NewGeneratorWithSeedAndAlgorithmOptions(seedStr, algorithmName string, options AlgorithmOptions) (*Generator, error) {
 	// Convert string seed to int64
 	seed, err := strconv.ParseInt(seedStr, 10, 64)
 	if err != nil {
@@ -73,14 +132,15 @@ func NewGeneratorWithSeedAndAlgorithm(seedStr, algorithmName string) (*Generator
 		seed = hashString(seedStr)
 	}
 
-	algorithm, err := NewAlgorithm(algorithmName)
+	algorithm, err := NewAlgorithmWithOptions(algorithmName, options)
 	if err != nil {
 		return nil, err
 	}
 
 	return &Generator{
-		rand:      rand.New(rand.NewSource(seed)), // #nosec G404 - not for cryptographic use
-		algorithm: algorithm,
+		rand:          rand.New(rand.NewSource(seed)), // #nosec G404 - not for cryptographic use
+		algorithm:     algorithm,
+		algorithmName: algorithmName,
 	}, nil
 }
 
@@ -95,6 +155,39 @@ func hashString(s string) int64 {
 
 // Generate creates a new maze with the specified dimensions using the configured algorithm.
 func (g *Generator) Generate(width, height int) *Maze {
+	maze, _ := g.GenerateWithOptions(width, height, GenerateOptions{}) // The default options never fail
+	return maze
+}
+
+// GenerateWithOptions creates a new maze like Generate and then applies the options.
+// It returns an error if an option cannot be satisfied, e.g. a start position that is not an open cell.
+func (g *Generator) GenerateWithOptions(width, height int, options GenerateOptions) (*Maze, error) {
+	if options.Mask != nil {
+		if options.Mask.GridWidth() != width || options.Mask.GridHeight() != height {
+			return nil, fmt.Errorf("mask of %dx%d cells needs a %dx%d maze, got %dx%d",
+				options.Mask.Cols, options.Mask.Rows, options.Mask.GridWidth(), options.Mask.GridHeight(), width, height)
+		}
+		if err := options.Mask.validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if options.Topology == TopologyCube {
+		return nil, fmt.Errorf("cube mazes are not square grids; use GenerateCube")
+	}
+
+	if options.Weave != 0 && (options.Mask != nil || g.hasConstraints() || options.Topology.wraps() || options.Sparse != 0) {
+		return nil, fmt.Errorf("weave cannot be combined with masks, rooms, obstacles, wrap-around topologies or sparseness")
+	}
+
+	var open, carvable *Mask
+	if g.hasConstraints() {
+		var err error
+		if open, carvable, err = g.constraintMasks(width, height, options.Mask); err != nil {
+			return nil, err
+		}
+	}
+
 	// Initialize grid with all walls
 	grid := make([][]bool, height)
 	for i := range grid {
@@ -112,16 +205,180 @@ func (g *Generator) Generate(width, height int) *Maze {
 		StartCol: 1,
 		GoalRow:  height - 2,
 		GoalCol:  width - 2,
+		Mask:     options.Mask,
+		Topology: options.Topology,
 	}
 
-	// Use selected algorithm to generate maze
-	g.algorithm.Generate(maze, 1, 1, g.rand)
+	if g.hasConstraints() {
+		// The algorithm carves around the rooms and obstacles, and the rooms
+		// are opened and given doors afterwards
+		start, goal := open.maskCells()
+		maze.StartRow, maze.StartCol = start.Row, start.Col
+		maze.GoalRow, maze.GoalCol = goal.Row, goal.Col
+		maze.layout = carvable
+		first, _ := carvable.maskCells()
+		g.carve(maze, first)
+		maze.layout = open
+		carveRooms(maze, g.rooms, g.rand)
+		maze.layout = nil
+		maze.Rooms = describeRooms(maze, g.rooms)
+	} else if options.Weave != 0 {
+		if err := g.weave(maze, options.Weave); err != nil {
+			return nil, err
+		}
+	} else if maze.Mask == nil {
+		// Use selected algorithm to generate maze
+		g.carve(maze, Position{Row: 1, Col: 1})
+	} else {
+		// Start and goal default to the first and last enabled cells
+		start, goal := maze.Mask.maskCells()
+		maze.StartRow, maze.StartCol = start.Row, start.Col
+		maze.GoalRow, maze.GoalCol = goal.Row, goal.Col
+		g.carve(maze, start)
+	}
 
 	// Ensure start and goal positions are paths
 	maze.Grid[maze.StartRow][maze.StartCol] = false
 	maze.Grid[maze.GoalRow][maze.GoalCol] = false
 
-	return maze
+	// Add loops
+	if options.Braid != 0 {
+		if err := Braid(maze, options.Braid, g.rand); err != nil {
+			return nil, err
+		}
+	}
+
+	// Move the start and goal markers
+	if options.LongestPath {
+		if options.Start.Mode != PlacementDefault || options.Goal.Mode != PlacementDefault {
+			return nil, fmt.Errorf("longest path placement cannot be combined with a start or goal position")
+		}
+		maze.PlaceAtDiameter()
+	} else if err := g.checkObstacles(options.Start, options.Goal); err != nil {
+		return nil, err
+	} else if err := placeEndpoints(maze, options.Start, options.Goal, g.rand); err != nil {
+		return nil, err
+	}
+
+	// Open the border
+	if err := placeOpenings(maze, options.Entrance, options.Exit, g.rand); err != nil {
+		return nil, err
+	}
+
+	// Fill in dead ends
+	if err := Sparsify(maze, options.Sparse); err != nil {
+		return nil, err
+	}
+	if maze.Rooms != nil {
+		maze.Rooms = describeRooms(maze, g.rooms) // Braiding and sparseness may move doors
+	}
+
+	return maze, nil
+}
+
+// GenerateGraph carves a spanning tree of any connected graph, starting from
+// the given cell. The configured algorithm must be a GraphAlgorithm; the
+// others build their mazes row by row or wall by wall on a square grid.
+func (g *Generator) GenerateGraph(graph Graph, start int) error {
+	graphAlgorithm, err := g.graphAlgorithm("arbitrary graphs")
+	if err != nil {
+		return err
+	}
+	if start < 0 || start >= graph.CellCount() {
+		return fmt.Errorf("start cell %d is outside the graph of %d cells", start, graph.CellCount())
+	}
+	for cell := 0; cell < graph.CellCount(); cell++ {
+		if degree := graph.Degree(cell); degree > MaxGraphDegree {
+			return fmt.Errorf("cell %d has %d neighbor slots, more than %d", cell, degree, MaxGraphDegree)
+		}
+	}
+
+	graphAlgorithm.GenerateGraph(graph, start, g.rand)
+	return nil
+}
+
+// carve runs the algorithm from the start cell. Mazes from algorithms that
+// cannot follow the mask or the topology by themselves are repaired afterwards.
+func (g *Generator) carve(maze *Maze, start Position) {
+	g.algorithm.Generate(maze, start.Row, start.Col, g.rand)
+	if maze.masked() {
+		applyMask(maze, g.rand)
+	}
+	if _, ok := g.algorithm.(GraphAlgorithm); maze.Topology.wraps() && !ok {
+		wrapAround(maze, g.rand)
+	}
+}
+
+// graphAlgorithm returns the generator's algorithm for carving a maze that
+// is not a rectangular grid, described by kind, or an error listing the
+// algorithms that can
+func (g *Generator) graphAlgorithm(kind string) (GraphAlgorithm, error) {
+	graphAlgorithm, ok := g.algorithm.(GraphAlgorithm)
+	if !ok {
+		return nil, fmt.Errorf("algorithm %s does not support %s (supported: %s)", g.algorithmName, kind, strings.Join(graphAlgorithmNames(), ", "))
+	}
+	return graphAlgorithm, nil
+}
+
+// Stream generates a maze row by row and writes it to w in the given format
+// without holding the whole grid in memory. It requires an algorithm that
+// implements RowGenerator and a renderer that implements RowRenderer; the
+// output is identical to rendering the result of Generate with the same seed.
+func (g *Generator) Stream(w io.Writer, width, height int, format string) error {
+	if g.hasConstraints() {
+		return fmt.Errorf("rooms and obstacles cannot be streamed")
+	}
+
+	rowGenerator, ok := g.algorithm.(RowGenerator)
+	if !ok {
+		return fmt.Errorf("algorithm does not support row-by-row streaming")
+	}
+
+	renderer, err := NewRenderer(format)
+	if err != nil {
+		return err
+	}
+	rowRenderer, ok := renderer.(RowRenderer)
+	if !ok {
+		return fmt.Errorf("format %s does not support row-by-row streaming", format)
+	}
+
+	// Only the metadata is filled in; the grid itself is never materialized
+	maze := &Maze{
+		Width:    width,
+		Height:   height,
+		StartRow: 1,
+		StartCol: 1,
+		GoalRow:  height - 2,
+		GoalCol:  width - 2,
+	}
+
+	// Each row is rendered once the row below it is known
+	out := bufio.NewWriter(w)
+	var above, current []bool
+	next := 0
+	writeRow := func(below []bool) error {
+		_, err := out.WriteString(rowRenderer.RenderRow(maze, next, above, current, below))
+		next++
+		return err
+	}
+
+	err = rowGenerator.GenerateRows(width, height, g.rand, func(row []bool) error {
+		if current != nil {
+			if err := writeRow(row); err != nil {
+				return err
+			}
+		}
+		above, current = current, row
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := writeRow(nil); err != nil {
+		return err
+	}
+	return out.Flush()
 }
 
 func (m *Maze) String() string {
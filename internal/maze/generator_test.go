@@ -1,6 +1,10 @@
 package maze
 
 import (
+	"bytes"
+	"math"
+	"math/rand"
+	"strconv"
 	"strings"
 	"testing"
 )
@@ -283,3 +287,556 @@ func TestMazeStringWithEmptySolutionPath(t *testing.T) {
 		t.Error("Expected maze to contain goal marker (○)")
 	}
 }
+
+// Test NewGeneratorWithSeedAndAlgorithmOptions function
+func TestNewGeneratorWithSeedAndAlgorithmOptions(t *testing.T) {
+	options := AlgorithmOptions{Strategy: "oldest"}
+	generator1, err := NewGeneratorWithSeedAndAlgorithmOptions("123", "growing-tree", options)
+	if err != nil {
+		t.Fatalf("Expected no error for valid options, got: %v", err)
+	}
+	generator2, err := NewGeneratorWithSeedAndAlgorithmOptions("123", "growing-tree", options)
+	if err != nil {
+		t.Fatalf("Expected no error for valid options, got: %v", err)
+	}
+
+	if generator1.Generate(11, 11).String() != generator2.Generate(11, 11).String() {
+		t.Error("Same seed, algorithm and options should produce identical mazes")
+	}
+
+	// Test invalid options
+	generator, err := NewGeneratorWithAlgorithmOptions("growing-tree", AlgorithmOptions{Strategy: "bogus"})
+	if err == nil {
+		t.Error("Expected error for invalid strategy")
+	}
+	if generator != nil {
+		t.Error("Expected nil generator for invalid strategy")
+	}
+}
+
+// Test that streaming produces exactly the same output as Generate + Render
+func TestStreamMatchesGenerate(t *testing.T) {
+	algorithms := []struct {
+		name    string
+		options AlgorithmOptions
+	}{
+		{name: "eller"},
+		{name: "binary-tree"},
+		{name: "binary-tree", options: AlgorithmOptions{Bias: "SW"}},
+		{name: "sidewinder"},
+		{name: "sidewinder", options: AlgorithmOptions{Bias: "SW"}},
+	}
+	sizes := [][2]int{{5, 5}, {11, 11}, {21, 7}, {7, 15}}
+
+	for _, algorithm := range algorithms {
+		for _, format := range []string{"ascii", "unicode"} {
+			for _, size := range sizes {
+				for _, seed := range []string{"1", "42", "maze"} {
+					generator, err := NewGeneratorWithSeedAndAlgorithmOptions(seed, algorithm.name, algorithm.options)
+					if err != nil {
+						t.Fatalf("Unexpected error: %v", err)
+					}
+					renderer, err := NewRenderer(format)
+					if err != nil {
+						t.Fatalf("Unexpected error: %v", err)
+					}
+					expected := renderer.Render(generator.Generate(size[0], size[1]))
+
+					generator, err = NewGeneratorWithSeedAndAlgorithmOptions(seed, algorithm.name, algorithm.options)
+					if err != nil {
+						t.Fatalf("Unexpected error: %v", err)
+					}
+					var buf bytes.Buffer
+					if err := generator.Stream(&buf, size[0], size[1], format); err != nil {
+						t.Fatalf("Unexpected stream error: %v", err)
+					}
+
+					if buf.String() != expected {
+						t.Errorf("Streamed %s %s output for %dx%d seed %s differs.\nExpected:\n%s\nGot:\n%s",
+							algorithm.name, format, size[0], size[1], seed, expected, buf.String())
+					}
+				}
+			}
+		}
+	}
+}
+
+// Test that streaming reports unsupported algorithms and formats
+func TestStreamUnsupported(t *testing.T) {
+	generator, err := NewGeneratorWithSeedAndAlgorithm("1", "dfs")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := generator.Stream(&buf, 9, 9, "ascii"); err == nil {
+		t.Error("Expected error when streaming with a non-row algorithm")
+	}
+
+	generator, err = NewGeneratorWithSeedAndAlgorithm("1", "eller")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := generator.Stream(&buf, 9, 9, "json"); err == nil {
+		t.Error("Expected error when streaming JSON output")
+	}
+	if err := generator.Stream(&buf, 9, 9, "invalid"); err == nil {
+		t.Error("Expected error when streaming an unknown format")
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Expected no output on error, got %q", buf.String())
+	}
+}
+
+func TestParsePlacement(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Placement
+		wantErr  bool
+	}{
+		{input: "", expected: Placement{Mode: PlacementDefault}},
+		{input: "3,5", expected: Placement{Mode: PlacementFixed, Row: 3, Col: 5}},
+		{input: " 7 , 1 ", expected: Placement{Mode: PlacementFixed, Row: 7, Col: 1}},
+		{input: "random", expected: Placement{Mode: PlacementRandom}},
+		{input: "Farthest", expected: Placement{Mode: PlacementFarthest}},
+		{input: "center", expected: Placement{Mode: PlacementCenter}},
+		{input: "3", wantErr: true},
+		{input: "3,5,7", wantErr: true},
+		{input: "a,b", wantErr: true},
+		{input: "middle", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		placement, err := ParsePlacement(tt.input)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParsePlacement(%q): expected error", tt.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParsePlacement(%q): unexpected error: %v", tt.input, err)
+			continue
+		}
+		if placement != tt.expected {
+			t.Errorf("ParsePlacement(%q) = %+v, expected %+v", tt.input, placement, tt.expected)
+		}
+		if reparsed, _ := ParsePlacement(placement.String()); reparsed != placement {
+			t.Errorf("ParsePlacement(%q).String() does not round-trip: %q", tt.input, placement.String())
+		}
+	}
+}
+
+func TestGenerateWithOptionsPlacement(t *testing.T) {
+	generate := func(options GenerateOptions) (*Maze, error) {
+		generator, err := NewGeneratorWithSeedAndAlgorithm("42", "kruskal")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return generator.GenerateWithOptions(21, 15, options)
+	}
+
+	// Default options match Generate
+	generator, _ := NewGeneratorWithSeedAndAlgorithm("42", "kruskal")
+	plain := generator.Generate(21, 15)
+	maze, err := generate(GenerateOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if maze.String() != plain.String() {
+		t.Error("Default options should produce the same maze as Generate")
+	}
+
+	// Fixed and center positions
+	maze, err = generate(GenerateOptions{Start: Placement{Mode: PlacementCenter}, Goal: Placement{Mode: PlacementFixed, Row: 1, Col: 19}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if maze.StartRow != 7 || maze.StartCol != 11 {
+		t.Errorf("Expected center start (7,11), got (%d,%d)", maze.StartRow, maze.StartCol)
+	}
+	if maze.GoalRow != 1 || maze.GoalCol != 19 {
+		t.Errorf("Expected goal (1,19), got (%d,%d)", maze.GoalRow, maze.GoalCol)
+	}
+	if path := FindPath(maze); path == nil || path[0] != (Position{Row: 7, Col: 11}) || path[len(path)-1] != (Position{Row: 1, Col: 19}) {
+		t.Error("Expected the solution path to run between the custom positions")
+	}
+
+	// Farthest goal is at maximal distance from the start
+	maze, err = generate(GenerateOptions{Goal: Placement{Mode: PlacementFarthest}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	distances := Distances(maze, Position{Row: maze.StartRow, Col: maze.StartCol})
+	for _, cell := range pathCells(maze) {
+		if distances[cell.Row][cell.Col] > distances[maze.GoalRow][maze.GoalCol] {
+			t.Errorf("Cell (%d,%d) is farther from the start than the goal", cell.Row, cell.Col)
+		}
+	}
+
+	// Random positions are reproducible, distinct path cells
+	options := GenerateOptions{Start: Placement{Mode: PlacementRandom}, Goal: Placement{Mode: PlacementRandom}}
+	maze1, err := generate(options)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	maze2, _ := generate(options)
+	if maze1.String() != maze2.String() {
+		t.Error("Random placements with the same seed should be reproducible")
+	}
+	if maze1.StartRow == maze1.GoalRow && maze1.StartCol == maze1.GoalCol {
+		t.Error("Random start and goal should differ")
+	}
+	if !isPathCell(maze1, Position{Row: maze1.StartRow, Col: maze1.StartCol}) || !isPathCell(maze1, Position{Row: maze1.GoalRow, Col: maze1.GoalCol}) {
+		t.Error("Random start and goal should be path cells")
+	}
+
+	// Invalid placements
+	invalid := []GenerateOptions{
+		{Start: Placement{Mode: PlacementFixed, Row: 2, Col: 3}}, // Passage position
+		{Start: Placement{Mode: PlacementFixed, Row: 0, Col: 1}}, // Border
+		{Goal: Placement{Mode: PlacementFixed, Row: 15, Col: 1}}, // Out of bounds
+		{Goal: Placement{Mode: PlacementFixed, Row: 1, Col: 1}},  // Same as the start
+		{Start: Placement{Mode: PlacementFarthest}, Goal: Placement{Mode: PlacementFarthest}},
+	}
+	for _, options := range invalid {
+		maze, err := generate(options)
+		if err == nil {
+			t.Errorf("Expected error for start %q, goal %q", options.Start, options.Goal)
+		}
+		if maze != nil {
+			t.Errorf("Expected nil maze for start %q, goal %q", options.Start, options.Goal)
+		}
+	}
+}
+
+func TestGenerateWithOptionsPlacementErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  GenerateOptions
+		expected string
+	}{
+		{"even row", GenerateOptions{Start: Placement{Mode: PlacementFixed, Row: 2, Col: 3}}, "start 2,3 is not a cell position"},
+		{"border", GenerateOptions{Start: Placement{Mode: PlacementFixed, Row: 0, Col: 1}}, "start 0,1 is outside the maze: row must be in 1..13 and column in 1..19"},
+		{"out of bounds", GenerateOptions{Goal: Placement{Mode: PlacementFixed, Row: 15, Col: 1}}, "goal 15,1 is outside the maze"},
+	}
+	for _, tt := range tests {
+		generator, _ := NewGeneratorWithSeedAndAlgorithm("42", "kruskal")
+		_, err := generator.GenerateWithOptions(21, 15, tt.options)
+		if err == nil || !strings.Contains(err.Error(), tt.expected) {
+			t.Errorf("%s: expected error containing %q, got %v", tt.name, tt.expected, err)
+		}
+	}
+}
+
+func TestGenerateWithOptionsPlacementAvoidsOtherEndpoint(t *testing.T) {
+	// A 5x5 maze has only four cells, so random and center endpoints often
+	// fall on the other endpoint unless they avoid it
+	placements := []Placement{
+		{},
+		{Mode: PlacementFixed, Row: 1, Col: 3},
+		{Mode: PlacementRandom},
+		{Mode: PlacementCenter},
+		{Mode: PlacementFarthest},
+	}
+	for _, start := range placements {
+		for _, goal := range placements {
+			if start.Mode == PlacementFarthest && goal.Mode == PlacementFarthest || start.Mode == PlacementFixed && goal.Mode == PlacementFixed {
+				continue
+			}
+			for seed := 1; seed <= 30; seed++ {
+				generator, _ := NewGeneratorWithSeedAndAlgorithm(strconv.Itoa(seed), "kruskal")
+				maze, err := generator.GenerateWithOptions(5, 5, GenerateOptions{Start: start, Goal: goal})
+				if err != nil {
+					t.Fatalf("Start %q, goal %q, seed %d: unexpected error: %v", start, goal, seed, err)
+				}
+				if maze.StartRow == maze.GoalRow && maze.StartCol == maze.GoalCol {
+					t.Fatalf("Start %q, goal %q, seed %d: start and goal are the same cell", start, goal, seed)
+				}
+			}
+		}
+	}
+}
+
+func TestGenerateWithOptionsLongestPath(t *testing.T) {
+	generator, err := NewGeneratorWithSeedAndAlgorithm("7", "kruskal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	maze, err := generator.GenerateWithOptions(21, 21, GenerateOptions{LongestPath: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	start, goal := FindDiameter(maze)
+	if maze.StartRow != start.Row || maze.StartCol != start.Col || maze.GoalRow != goal.Row || maze.GoalCol != goal.Col {
+		t.Errorf("Expected start and goal at the diameter ends (%d,%d) and (%d,%d)", start.Row, start.Col, goal.Row, goal.Col)
+	}
+
+	// The diameter is never shorter than the default corner-to-corner path
+	generator, _ = NewGeneratorWithSeedAndAlgorithm("7", "kruskal")
+	corners := generator.Generate(21, 21)
+	if len(FindPath(maze)) < len(FindPath(corners)) {
+		t.Errorf("Longest path %d is shorter than the corner path %d", len(FindPath(maze)), len(FindPath(corners)))
+	}
+
+	// PlaceAtDiameter clears a stale solution
+	corners.SolutionPath = FindPath(corners)
+	corners.PlaceAtDiameter()
+	if corners.SolutionPath != nil {
+		t.Error("Expected PlaceAtDiameter to clear the solution path")
+	}
+	if corners.String() != maze.String() {
+		t.Error("Expected PlaceAtDiameter to match the LongestPath option")
+	}
+
+	// Longest path cannot be combined with explicit positions
+	maze, err = generator.GenerateWithOptions(21, 21, GenerateOptions{LongestPath: true, Start: Placement{Mode: PlacementCenter}})
+	if err == nil || maze != nil {
+		t.Error("Expected an error when combining LongestPath with a start position")
+	}
+}
+
+func TestParseSide(t *testing.T) {
+	for _, name := range []string{"top", "right", "bottom", "left", "random"} {
+		side, err := ParseSide(name)
+		if err != nil {
+			t.Errorf("ParseSide(%q): unexpected error: %v", name, err)
+		}
+		if side.String() != name {
+			t.Errorf("ParseSide(%q).String() = %q", name, side.String())
+		}
+	}
+	if side, err := ParseSide(""); err != nil || side != SideNone {
+		t.Errorf("ParseSide(\"\") = %v, %v; expected SideNone", side, err)
+	}
+	if _, err := ParseSide("north-west"); err == nil {
+		t.Error("Expected error for an unknown side")
+	}
+}
+
+func TestGenerateWithOptionsOpenings(t *testing.T) {
+	tests := []struct {
+		name             string
+		options          GenerateOptions
+		entrance, exit   Position
+		expectedOpenings int
+	}{
+		{name: "top and bottom", options: GenerateOptions{Entrance: SideTop, Exit: SideBottom}, entrance: Position{Row: 0, Col: 1}, exit: Position{Row: 14, Col: 19}, expectedOpenings: 2},
+		{name: "left and right", options: GenerateOptions{Entrance: SideLeft, Exit: SideRight}, entrance: Position{Row: 1, Col: 0}, exit: Position{Row: 13, Col: 20}, expectedOpenings: 2},
+		{name: "entrance only", options: GenerateOptions{Entrance: SideLeft}, entrance: Position{Row: 1, Col: 0}, exit: Position{Row: 13, Col: 19}, expectedOpenings: 1},
+		{name: "in line with a custom start", options: GenerateOptions{Start: Placement{Mode: PlacementCenter}, Entrance: SideBottom}, entrance: Position{Row: 14, Col: 11}, exit: Position{Row: 13, Col: 19}, expectedOpenings: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generator, _ := NewGeneratorWithSeedAndAlgorithm("42", "kruskal")
+			maze, err := generator.GenerateWithOptions(21, 15, tt.options)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if start := (Position{Row: maze.StartRow, Col: maze.StartCol}); start != tt.entrance {
+				t.Errorf("Expected start at %v, got %v", tt.entrance, start)
+			}
+			if goal := (Position{Row: maze.GoalRow, Col: maze.GoalCol}); goal != tt.exit {
+				t.Errorf("Expected goal at %v, got %v", tt.exit, goal)
+			}
+			if len(maze.Openings) != tt.expectedOpenings {
+				t.Errorf("Expected %d openings, got %d", tt.expectedOpenings, len(maze.Openings))
+			}
+			for _, opening := range maze.Openings {
+				if maze.Grid[opening.Row][opening.Col] {
+					t.Errorf("Opening %v is still a wall", opening)
+				}
+			}
+
+			path := FindPath(maze)
+			if path == nil || path[0] != tt.entrance || path[len(path)-1] != tt.exit {
+				t.Error("Expected the solution to run from the entrance to the exit")
+			}
+		})
+	}
+
+	// Random sides are reproducible and always on the border
+	options := GenerateOptions{Entrance: SideRandom, Exit: SideRandom}
+	generator1, _ := NewGeneratorWithSeedAndAlgorithm("9", "prim")
+	generator2, _ := NewGeneratorWithSeedAndAlgorithm("9", "prim")
+	maze1, err := generator1.GenerateWithOptions(21, 15, options)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	maze2, _ := generator2.GenerateWithOptions(21, 15, options)
+	if maze1.String() != maze2.String() {
+		t.Error("Random openings with the same seed should be reproducible")
+	}
+	for _, opening := range maze1.Openings {
+		if opening.Row != 0 && opening.Row != 14 && opening.Col != 0 && opening.Col != 20 {
+			t.Errorf("Opening %v is not on the border", opening)
+		}
+	}
+
+	// Openings that coincide are rejected
+	generator, _ := NewGeneratorWithSeedAndAlgorithm("42", "kruskal")
+	maze, err := generator.GenerateWithOptions(21, 15, GenerateOptions{Goal: Placement{Mode: PlacementFixed, Row: 1, Col: 3}, Entrance: SideTop, Exit: SideTop, Start: Placement{Mode: PlacementFixed, Row: 3, Col: 3}})
+	if err == nil || maze != nil {
+		t.Error("Expected an error for coinciding openings")
+	}
+}
+
+func TestBraid(t *testing.T) {
+	for _, name := range GetSupportedAlgorithms() {
+		t.Run(name, func(t *testing.T) {
+			generator, err := NewGeneratorWithSeedAndAlgorithm("42", name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			maze := generator.Generate(31, 21)
+			cells := len(pathCells(maze))
+			deadEnds := len(DeadEnds(maze))
+
+			if err := Braid(maze, 1, rand.New(rand.NewSource(1))); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if remaining := DeadEnds(maze); len(remaining) != 0 {
+				t.Errorf("Expected no dead ends after a full braid, got %d", len(remaining))
+			}
+
+			// All cells stay connected, and the maze now has loops
+			distances := Distances(maze, Position{Row: 1, Col: 1})
+			for _, cell := range pathCells(maze) {
+				if distances[cell.Row][cell.Col] < 0 {
+					t.Fatalf("Cell (%d,%d) is unreachable after braiding", cell.Row, cell.Col)
+				}
+			}
+			if deadEnds > 0 && countOpen(maze) <= 2*cells-1 {
+				t.Error("Expected braiding to add passages")
+			}
+		})
+	}
+}
+
+func TestBraidFraction(t *testing.T) {
+	generator, _ := NewGeneratorWithSeedAndAlgorithm("42", "prim")
+	maze := generator.Generate(41, 41)
+	before := len(DeadEnds(maze))
+
+	original := maze.String()
+	if err := Braid(maze, 0, rand.New(rand.NewSource(1))); err != nil || maze.String() != original {
+		t.Error("A braid fraction of 0 should leave the maze unchanged")
+	}
+
+	if err := Braid(maze, 0.5, rand.New(rand.NewSource(1))); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if after := len(DeadEnds(maze)); after > before-before/2 {
+		t.Errorf("Expected at least half of %d dead ends removed, %d remain", before, after)
+	}
+
+	for _, fraction := range []float64{-0.1, 1.1, math.NaN()} {
+		if err := Braid(maze, fraction, rand.New(rand.NewSource(1))); err == nil {
+			t.Errorf("Expected error for braid fraction %v", fraction)
+		}
+	}
+}
+
+func TestGenerateWithOptionsBraid(t *testing.T) {
+	generate := func() *Maze {
+		generator, _ := NewGeneratorWithSeedAndAlgorithm("3", "dfs")
+		maze, err := generator.GenerateWithOptions(21, 21, GenerateOptions{Braid: 0.75})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return maze
+	}
+
+	maze := generate()
+	if maze.String() != generate().String() {
+		t.Error("Braided mazes with the same seed should be reproducible")
+	}
+
+	// FindPath returns the shortest of the several routes
+	path := FindPath(maze)
+	distances := Distances(maze, Position{Row: maze.StartRow, Col: maze.StartCol})
+	if path == nil || len(path)-1 != distances[maze.GoalRow][maze.GoalCol] {
+		t.Errorf("Expected a shortest path of length %d", distances[maze.GoalRow][maze.GoalCol])
+	}
+
+	generator, _ := NewGeneratorWithSeedAndAlgorithm("3", "dfs")
+	if _, err := generator.GenerateWithOptions(21, 21, GenerateOptions{Braid: 2}); err == nil {
+		t.Error("Expected error for an invalid braid fraction")
+	}
+}
+
+// countOpen counts the open positions of the maze grid
+func countOpen(maze *Maze) int {
+	count := 0
+	for _, row := range maze.Grid {
+		for _, wall := range row {
+			if !wall {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+func TestSparsify(t *testing.T) {
+	for _, name := range []string{"dfs", "kruskal", "division", "sidewinder"} {
+		t.Run(name, func(t *testing.T) {
+			generator, _ := NewGeneratorWithSeedAndAlgorithm("42", name)
+			maze := generator.Generate(31, 21)
+			path := FindPath(maze)
+			before := countOpen(maze)
+
+			// A few passes open up solid areas but keep the solution intact
+			if err := Sparsify(maze, 2); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if countOpen(maze) >= before {
+				t.Error("Expected sparsifying to fill in dead ends")
+			}
+			if after := FindPath(maze); len(after) != len(path) {
+				t.Errorf("Expected the solution of length %d to be kept, got %d", len(path), len(after))
+			}
+
+			// Enough passes leave nothing but the route between start and goal
+			if err := Sparsify(maze, maze.Width*maze.Height); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if open := countOpen(maze); open != len(path) {
+				t.Errorf("Expected only the %d solution positions to stay open, got %d", len(path), open)
+			}
+		})
+	}
+
+	generator := NewGeneratorWithSeed("1")
+	maze := generator.Generate(11, 11)
+	original := maze.String()
+	if err := Sparsify(maze, 0); err != nil || maze.String() != original {
+		t.Error("Zero sparse passes should leave the maze unchanged")
+	}
+	if err := Sparsify(maze, -1); err == nil {
+		t.Error("Expected error for negative sparse passes")
+	}
+}
+
+func TestGenerateWithOptionsSparseKeepsOpenings(t *testing.T) {
+	generator, _ := NewGeneratorWithSeedAndAlgorithm("5", "prim")
+	maze, err := generator.GenerateWithOptions(21, 21, GenerateOptions{
+		Start:    Placement{Mode: PlacementCenter},
+		Entrance: SideLeft,
+		Exit:     SideTop,
+		Sparse:   1000,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	path := FindPath(maze)
+	if path == nil {
+		t.Fatal("Expected the entrance and exit to stay connected")
+	}
+	if open := countOpen(maze); open != len(path) {
+		t.Errorf("Expected only the %d solution positions to stay open, got %d", len(path), open)
+	}
+}
@@ -0,0 +1,299 @@
+package maze
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// MaxGraphDegree is the largest number of neighbor slots a Graph cell may
+// have. The graph algorithms keep slot numbers in a byte.
+const MaxGraphDegree = 255
+
+// Graph is the structure that graph algorithms carve a maze over: cells
+// numbered from 0, each with a fixed list of neighbor slots. A rectangular
+// maze is one Graph (see gridGraph), a hexagonal grid is another and a list
+// of edges supplied by the user a third (see EdgeGraph), so the same
+// algorithm implementations work on all of them.
+type Graph interface {
+	// CellCount returns the number of cells
+	CellCount() int
+	// Degree returns the number of neighbor slots of a cell, at most MaxGraphDegree
+	Degree(cell int) int
+	// Neighbor returns the cell in the given slot, or -1 if the slot is empty,
+	// e.g. because the cell lies on the edge of the grid
+	Neighbor(cell, slot int) int
+	// Link opens the passage from a cell to its neighbor in the given slot
+	Link(cell, slot int)
+}
+
+// GraphAlgorithm is implemented by algorithms that only need to know the
+// neighbors of each cell, so they can carve a perfect maze over any Graph.
+// Their Generate method carves the rectangular grid as a gridGraph.
+type GraphAlgorithm interface {
+	Algorithm
+	// GenerateGraph carves a spanning tree of the graph starting from a
+	// cell. The graph must be connected.
+	GenerateGraph(graph Graph, start int, rng *rand.Rand)
+}
+
+// WeightedGraph is a Graph whose passages have weights. Kruskal's algorithm
+// opens the lightest passages first and so carves a minimum spanning tree;
+// the other algorithms ignore weights.
+type WeightedGraph interface {
+	Graph
+	// Weight returns the weight of the passage from a cell through a slot
+	Weight(cell, slot int) float64
+}
+
+// generateOnGrid runs a graph algorithm over the cells of a rectangular maze
+func generateOnGrid(algorithm GraphAlgorithm, maze *Maze, startRow, startCol int, rng *rand.Rand) {
+	graph := newGridGraph(maze)
+	maze.Grid[startRow][startCol] = false
+	algorithm.GenerateGraph(graph, graph.cell(Position{Row: startRow, Col: startCol}), rng)
+}
+
+// gridGraph presents the enabled cells of a rectangular maze as a Graph.
+// Cells are numbered in row-major order and their slots are cellDirections,
+// so links follow the maze's topology and never leave its mask.
+type gridGraph struct {
+	maze     *Maze
+	cellCols int
+	cells    []int32 // row-major index of each graph cell, or nil if no cell is masked out
+	index    []int32 // graph cell of each row-major index, -1 if masked out; nil if none are
+}
+
+// newGridGraph numbers the enabled cells of the maze
+func newGridGraph(maze *Maze) *gridGraph {
+	g := &gridGraph{maze: maze, cellCols: (maze.Width - 1) / 2}
+	if !maze.masked() {
+		return g
+	}
+
+	cellCount := g.cellCols * ((maze.Height - 1) / 2)
+	g.index = make([]int32, cellCount)
+	for i := range g.index {
+		g.index[i] = -1
+		if maze.cellEnabled(2*(i/g.cellCols)+1, 2*(i%g.cellCols)+1) {
+			g.index[i] = int32(len(g.cells)) // #nosec G115 - cell counts fit in int32
+			g.cells = append(g.cells, int32(i))
+		}
+	}
+	return g
+}
+
+// CellCount implements the Graph interface
+func (g *gridGraph) CellCount() int {
+	if g.index == nil {
+		return g.cellCols * ((g.maze.Height - 1) / 2)
+	}
+	return len(g.cells)
+}
+
+// Degree implements the Graph interface: every cell has four slots
+func (g *gridGraph) Degree(int) int {
+	return len(cellDirections)
+}
+
+// Neighbor implements the Graph interface
+func (g *gridGraph) Neighbor(cell, slot int) int {
+	pos := g.position(cell)
+	dir := cellDirections[slot]
+	next, _, ok := g.maze.neighbor(pos.Row, pos.Col, dir.Row, dir.Col)
+	if !ok {
+		return -1
+	}
+	return g.cell(next)
+}
+
+// Link implements the Graph interface by opening both cells and the wall between them
+func (g *gridGraph) Link(cell, slot int) {
+	pos := g.position(cell)
+	dir := cellDirections[slot]
+	next, wall, _ := g.maze.neighbor(pos.Row, pos.Col, dir.Row, dir.Col)
+	g.maze.Grid[pos.Row][pos.Col] = false
+	g.maze.Grid[next.Row][next.Col] = false
+	g.maze.setWall(wall, false)
+}
+
+// position returns the grid position of a graph cell
+func (g *gridGraph) position(cell int) Position {
+	if g.cells != nil {
+		cell = int(g.cells[cell])
+	}
+	return Position{Row: 2*(cell/g.cellCols) + 1, Col: 2*(cell%g.cellCols) + 1}
+}
+
+// cell returns the graph cell at an enabled grid position
+func (g *gridGraph) cell(pos Position) int {
+	i := (pos.Row-1)/2*g.cellCols + (pos.Col-1)/2
+	if g.index != nil {
+		return int(g.index[i])
+	}
+	return i
+}
+
+// cellLayout is the fixed structure of a maze whose cells are not the squares
+// of a Grid, e.g. a hexagonal grid: the neighbor behind each slot of each
+// cell. A linkedGraph records the passages carved into it.
+type cellLayout interface {
+	CellCount() int
+	Degree(cell int) int
+	Neighbor(cell, slot int) int
+	// reverse returns the slot through which the neighbor in the given slot leads back
+	reverse(cell, slot int) int
+}
+
+// maxLinkedDegree is the largest number of slots a cell of a linkedGraph may
+// have: one for each bit of its links. It is below MaxGraphDegree, which is
+// enough for every built-in cell layout.
+const maxLinkedDegree = 16
+
+// linkedGraph is a Graph over a cell layout that records its open passages
+type linkedGraph struct {
+	cellLayout
+	links []uint16 // Bit s of a cell is set when its passage through slot s is open
+}
+
+// newLinkedGraph creates a graph over the layout with every passage closed.
+// It panics if a cell has more than maxLinkedDegree slots.
+func newLinkedGraph(layout cellLayout) *linkedGraph {
+	for cell := 0; cell < layout.CellCount(); cell++ {
+		if degree := layout.Degree(cell); degree > maxLinkedDegree {
+			panic(fmt.Sprintf("maze: cell %d has %d slots, more than the %d a linkedGraph can record", cell, degree, maxLinkedDegree))
+		}
+	}
+	return &linkedGraph{cellLayout: layout, links: make([]uint16, layout.CellCount())}
+}
+
+// Link implements the Graph interface, opening the passage from both sides
+func (g *linkedGraph) Link(cell, slot int) {
+	next := g.Neighbor(cell, slot)
+	g.links[cell] |= 1 << slot
+	g.links[next] |= 1 << g.reverse(cell, slot)
+}
+
+// linked reports whether the passage from a cell through a slot is open
+func (g *linkedGraph) linked(cell, slot int) bool {
+	return g.links[cell]&(1<<slot) != 0
+}
+
+// distances returns the number of passages between a cell and every other
+// cell, found with BFS, or -1 for cells that cannot be reached
+func (g *linkedGraph) distances(from int) []int {
+	distances := make([]int, g.CellCount())
+	for i := range distances {
+		distances[i] = -1
+	}
+	distances[from] = 0
+	queue := []int{from}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for slot := 0; slot < g.Degree(current); slot++ {
+			if next := g.Neighbor(current, slot); next >= 0 && distances[next] < 0 && g.linked(current, slot) {
+				distances[next] = distances[current] + 1
+				queue = append(queue, next)
+			}
+		}
+	}
+	return distances
+}
+
+// path returns the cells on the shortest route between two cells through
+// open passages, or nil if they are not connected
+func (g *linkedGraph) path(from, to int) []int {
+	distances := g.distances(from)
+	if distances[to] < 0 {
+		return nil
+	}
+
+	// Walk back from the end, always to a neighbor one step closer to the start
+	path := make([]int, distances[to]+1)
+	path[len(path)-1] = to
+	for i := len(path) - 1; i > 0; i-- {
+		for slot := 0; slot < g.Degree(path[i]); slot++ {
+			if next := g.Neighbor(path[i], slot); next >= 0 && distances[next] == i-1 && g.linked(path[i], slot) {
+				path[i-1] = next
+				break
+			}
+		}
+	}
+	return path
+}
+
+// positionLayout is a cellLayout whose cells are also addressed by a
+// position of type P, such as a Position in cell coordinates
+type positionLayout[P comparable] interface {
+	cellLayout
+	cell(pos P) int
+	position(cell int) P
+}
+
+// layoutMaze is the part every maze over a positionLayout shares: its start,
+// goal and solution, and the passages carved between its cells. HexMaze and
+// the other shapes embed it and add their size and rendering.
+type layoutMaze[P comparable] struct {
+	Start        P
+	Goal         P
+	SolutionPath []P // Optional solution path from start to goal
+
+	layout positionLayout[P]
+	graph  *linkedGraph
+}
+
+// newLayoutMaze creates a maze over the layout with every wall standing
+func newLayoutMaze[P comparable](layout positionLayout[P], start, goal P) layoutMaze[P] {
+	return layoutMaze[P]{Start: start, Goal: goal, layout: layout, graph: newLinkedGraph(layout)}
+}
+
+// generate carves the maze with the generator's algorithm, starting from a
+// cell. kind names the shape in the error for an algorithm that is not a
+// GraphAlgorithm.
+func (m *layoutMaze[P]) generate(g *Generator, kind string, from P) error {
+	graphAlgorithm, err := g.graphAlgorithm(kind)
+	if err != nil {
+		return err
+	}
+	graphAlgorithm.GenerateGraph(m.graph, m.layout.cell(from), g.rand)
+	return nil
+}
+
+// Degree returns the number of neighbor slots of the cell at pos
+func (m *layoutMaze[P]) Degree(pos P) int {
+	return m.layout.Degree(m.layout.cell(pos))
+}
+
+// Neighbor returns the cell next to pos in the given slot, if there is one
+func (m *layoutMaze[P]) Neighbor(pos P, slot int) (P, bool) {
+	next := m.layout.Neighbor(m.layout.cell(pos), slot)
+	if next < 0 {
+		var none P
+		return none, false
+	}
+	return m.layout.position(next), true
+}
+
+// Linked reports whether the passage from pos through the given slot is open
+func (m *layoutMaze[P]) Linked(pos P, slot int) bool {
+	return m.graph.linked(m.layout.cell(pos), slot)
+}
+
+// link opens the passage from pos through the given slot
+func (m *layoutMaze[P]) link(pos P, slot int) {
+	m.graph.Link(m.layout.cell(pos), slot)
+}
+
+// Solve sets SolutionPath to the shortest path from the start to the goal,
+// as a list of cells, or to nil if there is none
+func (m *layoutMaze[P]) Solve() {
+	cells := m.graph.path(m.layout.cell(m.Start), m.layout.cell(m.Goal))
+	if cells == nil {
+		m.SolutionPath = nil
+		return
+	}
+
+	m.SolutionPath = make([]P, len(cells))
+	for i, cell := range cells {
+		m.SolutionPath[i] = m.layout.position(cell)
+	}
+}
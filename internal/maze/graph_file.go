@@ -0,0 +1,548 @@
+package maze
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// LoadGraph reads a graph from a file, which is either JSON (see
+// ParseGraphJSON) or Graphviz DOT (see ParseGraphDOT)
+func LoadGraph(path string) (*GraphMaze, error) {
+	file, err := os.Open(path) // #nosec G304 - the graph path is chosen by the user
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+	return ParseGraph(file)
+}
+
+// ParseGraph reads a graph in JSON if it starts with '{', and in DOT otherwise
+func ParseGraph(r io.Reader) (*GraphMaze, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		return ParseGraphJSON(bytes.NewReader(data))
+	}
+	return ParseGraphDOT(bytes.NewReader(data))
+}
+
+// graphBuilder collects the nodes and edges of a graph file, numbering the
+// nodes in the order they first appear
+type graphBuilder struct {
+	nodes []GraphNode
+	index map[string]int
+	edges []GraphEdge
+}
+
+// node returns the index of the node with the given ID, adding it if it is new
+func (b *graphBuilder) node(id string) int {
+	if b.index == nil {
+		b.index = make(map[string]int)
+	}
+	if i, ok := b.index[id]; ok {
+		return i
+	}
+	b.index[id] = len(b.nodes)
+	b.nodes = append(b.nodes, GraphNode{ID: id})
+	return len(b.nodes) - 1
+}
+
+// build creates the maze over the collected graph
+func (b *graphBuilder) build() (*GraphMaze, error) {
+	if len(b.nodes) == 0 {
+		return nil, fmt.Errorf("graph has no nodes")
+	}
+	return NewGraphMaze(b.nodes, b.edges)
+}
+
+// graphID is a node ID in a JSON graph, given as a string or a number
+type graphID string
+
+// UnmarshalJSON accepts a string or a number
+func (id *graphID) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*id = graphID(s)
+		return nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("node ID must be a string or a number, got %s", data)
+	}
+	*id = graphID(n)
+	return nil
+}
+
+// graphFileJSON is the layout of a JSON graph file
+type graphFileJSON struct {
+	Nodes []struct {
+		ID graphID  `json:"id"`
+		X  *float64 `json:"x"`
+		Y  *float64 `json:"y"`
+	} `json:"nodes"`
+	Edges []struct {
+		From   graphID  `json:"from"`
+		To     graphID  `json:"to"`
+		Weight *float64 `json:"weight"`
+	} `json:"edges"`
+}
+
+// ParseGraphJSON parses a graph like
+//
+//	{
+//	  "nodes": [{"id": "a", "x": 0, "y": 0}, {"id": "b", "x": 40, "y": 10}],
+//	  "edges": [{"from": "a", "to": "b", "weight": 2}]
+//	}
+//
+// Node IDs are strings or numbers. Positions are optional, with y growing
+// downwards, and weights default to 1. Nodes that are not listed are added
+// when an edge first names them.
+func ParseGraphJSON(r io.Reader) (*GraphMaze, error) {
+	var file graphFileJSON
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&file); err != nil {
+		return nil, fmt.Errorf("invalid JSON graph: %v", err)
+	}
+
+	b := &graphBuilder{}
+	for _, node := range file.Nodes {
+		id := string(node.ID)
+		if _, ok := b.index[id]; ok {
+			return nil, fmt.Errorf("node %q is listed twice", id)
+		}
+		if (node.X == nil) != (node.Y == nil) {
+			return nil, fmt.Errorf("node %q needs both x and y, or neither", id)
+		}
+		i := b.node(id)
+		if node.X != nil {
+			b.nodes[i].X, b.nodes[i].Y, b.nodes[i].Placed = *node.X, *node.Y, true
+		}
+	}
+	for i, edge := range file.Edges {
+		if edge.From == "" || edge.To == "" {
+			return nil, fmt.Errorf("edge %d needs a from and a to node", i)
+		}
+		weight := 1.0
+		if edge.Weight != nil {
+			weight = *edge.Weight
+		}
+		b.edges = append(b.edges, GraphEdge{From: b.node(string(edge.From)), To: b.node(string(edge.To)), Weight: weight})
+	}
+	return b.build()
+}
+
+// ParseGraphDOT parses a graph in the Graphviz DOT language, such as
+//
+//	graph subway {
+//	  a [pos="0,0"]; b [pos="40,-10"];
+//	  a -- b [weight=2];
+//	  b -- c -- d;
+//	}
+//
+// Directed graphs are read as undirected. Repeated edges between the same two
+// nodes are an error, except in a strict graph, where they are merged as
+// Graphviz does and the last weight given wins. Node positions come from the
+// Graphviz pos attribute "x,y", whose y grows upwards, and edge weights from
+// the weight attribute, defaulting to 1. Subgraphs are flattened, and other
+// attributes, including defaults set with node and edge statements, are
+// ignored. Quoted IDs may escape a quote or a backslash with a backslash.
+func ParseGraphDOT(r io.Reader) (*GraphMaze, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := dotTokens(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("invalid DOT graph: %v", err)
+	}
+
+	p := &dotParser{tokens: tokens, builder: &graphBuilder{}}
+	if err := p.parseGraph(); err != nil {
+		return nil, fmt.Errorf("invalid DOT graph: %v", err)
+	}
+	return p.builder.build()
+}
+
+// dotToken is a token of the DOT language: an ID, which may have been
+// quoted, or a punctuation mark or edge operator
+type dotToken struct {
+	text   string
+	id     bool
+	quoted bool
+	line   int
+}
+
+// dotTokens splits DOT source into tokens, skipping comments
+func dotTokens(source string) ([]dotToken, error) {
+	var tokens []dotToken
+	runes := []rune(source)
+	line := 1
+	for i := 0; i < len(runes); {
+		c := runes[i]
+		switch {
+		case c == '\n':
+			line++
+			i++
+		case unicode.IsSpace(c):
+			i++
+		case c == '#' && (i == 0 || runes[i-1] == '\n'):
+			for i < len(runes) && runes[i] != '\n' {
+				i++ // Preprocessor output line
+			}
+		case c == '/' && i+1 < len(runes) && runes[i+1] == '/':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case c == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			start := line
+			for i += 2; i+1 < len(runes) && (runes[i] != '*' || runes[i+1] != '/'); i++ {
+				if runes[i] == '\n' {
+					line++
+				}
+			}
+			if i+1 >= len(runes) {
+				return nil, fmt.Errorf("line %d: unterminated comment", start)
+			}
+			i += 2
+		case c == '"':
+			var sb strings.Builder
+			start := line
+			for i++; ; i++ {
+				if i >= len(runes) {
+					return nil, fmt.Errorf("line %d: unterminated string", start)
+				}
+				if runes[i] == '\n' {
+					line++
+				}
+				if runes[i] == '\\' && i+1 < len(runes) && (runes[i+1] == '"' || runes[i+1] == '\\' || runes[i+1] == '\n') {
+					if runes[i+1] != '\n' {
+						sb.WriteRune(runes[i+1]) // Escaped quote or backslash
+					} else {
+						line++ // Line continuation
+					}
+					i++
+					continue
+				}
+				if runes[i] == '"' {
+					break
+				}
+				sb.WriteRune(runes[i])
+			}
+			i++
+			tokens = append(tokens, dotToken{text: sb.String(), id: true, quoted: true, line: start})
+		case c == '<':
+			// An HTML string runs to the matching '>'
+			depth, j := 0, i
+			for ; j < len(runes); j++ {
+				if runes[j] == '<' {
+					depth++
+				} else if runes[j] == '>' {
+					if depth--; depth == 0 {
+						break
+					}
+				}
+			}
+			if j == len(runes) {
+				return nil, fmt.Errorf("line %d: unterminated HTML string", line)
+			}
+			text := string(runes[i+1 : j])
+			tokens = append(tokens, dotToken{text: text, id: true, quoted: true, line: line})
+			line += strings.Count(text, "\n")
+			i = j + 1
+		case c == '-' && i+1 < len(runes) && (runes[i+1] == '-' || runes[i+1] == '>'):
+			tokens = append(tokens, dotToken{text: string(runes[i : i+2]), line: line})
+			i += 2
+		case strings.ContainsRune("{}[];,=:", c):
+			tokens = append(tokens, dotToken{text: string(c), line: line})
+			i++
+		case c == '_' || c == '-' || c == '.' || unicode.IsLetter(c) || unicode.IsDigit(c):
+			j := i + 1
+			for j < len(runes) && (runes[j] == '_' || runes[j] == '.' || unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j])) {
+				j++
+			}
+			tokens = append(tokens, dotToken{text: string(runes[i:j]), id: true, line: line})
+			i = j
+		default:
+			return nil, fmt.Errorf("line %d: unexpected character %q", line, c)
+		}
+	}
+	return tokens, nil
+}
+
+// dotParser reads the statements of a DOT graph into a graphBuilder
+type dotParser struct {
+	tokens  []dotToken
+	pos     int
+	builder *graphBuilder
+	strict  bool           // Whether repeated edges are merged
+	edges   map[[2]int]int // Index of the edge between two nodes, in a strict graph
+}
+
+// peek returns the next token, or an empty one at the end
+func (p *dotParser) peek() dotToken {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return dotToken{}
+}
+
+// keyword reports whether the next token is the given keyword, which DOT
+// matches regardless of case
+func (p *dotParser) keyword(word string) bool {
+	token := p.peek()
+	return token.id && !token.quoted && strings.EqualFold(token.text, word)
+}
+
+// punct reports whether the next token is the given punctuation mark
+func (p *dotParser) punct(mark string) bool {
+	token := p.peek()
+	return !token.id && token.text == mark
+}
+
+// expect consumes the given punctuation mark
+func (p *dotParser) expect(mark string) error {
+	if !p.punct(mark) {
+		return p.unexpected("'" + mark + "'")
+	}
+	p.pos++
+	return nil
+}
+
+// id consumes an ID
+func (p *dotParser) id() (string, error) {
+	token := p.peek()
+	if !token.id {
+		return "", p.unexpected("an ID")
+	}
+	p.pos++
+	return token.text, nil
+}
+
+// unexpected describes the next token when something else was wanted
+func (p *dotParser) unexpected(wanted string) error {
+	if p.pos >= len(p.tokens) {
+		return fmt.Errorf("expected %s, got the end of the file", wanted)
+	}
+	token := p.peek()
+	return fmt.Errorf("line %d: expected %s, got %q", token.line, wanted, token.text)
+}
+
+// parseGraph parses: [strict] (graph | digraph) [ID] '{' statements '}'
+func (p *dotParser) parseGraph() error {
+	if p.keyword("strict") {
+		p.strict, p.edges = true, make(map[[2]int]int)
+		p.pos++
+	}
+	if !p.keyword("graph") && !p.keyword("digraph") {
+		return p.unexpected("graph or digraph")
+	}
+	p.pos++
+	if p.peek().id {
+		p.pos++
+	}
+	if _, err := p.parseBlock(); err != nil {
+		return err
+	}
+	if p.pos < len(p.tokens) {
+		return p.unexpected("the end of the file")
+	}
+	return nil
+}
+
+// parseBlock parses '{' statements '}' and returns the nodes named in it
+func (p *dotParser) parseBlock() ([]int, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	var nodes []int
+	for !p.punct("}") {
+		if p.pos >= len(p.tokens) {
+			return nil, p.unexpected("'}'")
+		}
+		named, err := p.parseStatement()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, named...)
+		if p.punct(";") {
+			p.pos++
+		}
+	}
+	p.pos++
+	return nodes, nil
+}
+
+// parseStatement parses one statement and returns the nodes it names
+func (p *dotParser) parseStatement() ([]int, error) {
+	// Defaults for the graph, nodes or edges
+	if p.keyword("graph") || p.keyword("node") || p.keyword("edge") {
+		p.pos++
+		_, err := p.parseAttributes()
+		return nil, err
+	}
+	// A graph attribute: ID '=' ID
+	if p.peek().id && p.pos+1 < len(p.tokens) && !p.tokens[p.pos+1].id && p.tokens[p.pos+1].text == "=" {
+		p.pos += 2
+		_, err := p.id()
+		return nil, err
+	}
+
+	operand, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	if !p.punct("--") && !p.punct("->") {
+		if operand.subgraph {
+			return operand.nodes, nil
+		}
+		attributes, err := p.parseAttributes()
+		if err != nil {
+			return nil, err
+		}
+		return operand.nodes, p.placeNode(operand.nodes[0], attributes)
+	}
+
+	// An edge statement joins each operand to the next one
+	operands := []dotOperand{operand}
+	for p.punct("--") || p.punct("->") {
+		p.pos++
+		next, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, next)
+	}
+	attributes, err := p.parseAttributes()
+	if err != nil {
+		return nil, err
+	}
+	weight := 1.0
+	value, weighted := attributes["weight"]
+	if weighted {
+		// ParseFloat also accepts "nan" and "inf", which cannot be ordered
+		if weight, err = strconv.ParseFloat(value, 64); err != nil || math.IsNaN(weight) || math.IsInf(weight, 0) {
+			return nil, fmt.Errorf("invalid edge weight %q", value)
+		}
+	}
+
+	var named []int
+	for i, operand := range operands {
+		named = append(named, operand.nodes...)
+		if i == 0 {
+			continue
+		}
+		for _, from := range operands[i-1].nodes {
+			for _, to := range operand.nodes {
+				p.addEdge(from, to, weight, weighted)
+			}
+		}
+	}
+	return named, nil
+}
+
+// addEdge adds an edge to the graph. In a strict graph an edge between two
+// nodes that are already joined, in either direction, is merged into the
+// existing edge, taking its weight if it has one.
+func (p *dotParser) addEdge(from, to int, weight float64, weighted bool) {
+	if p.strict {
+		key := [2]int{min(from, to), max(from, to)}
+		if i, ok := p.edges[key]; ok {
+			if weighted {
+				p.builder.edges[i].Weight = weight
+			}
+			return
+		}
+		p.edges[key] = len(p.builder.edges)
+	}
+	p.builder.edges = append(p.builder.edges, GraphEdge{From: from, To: to, Weight: weight})
+}
+
+// dotOperand is one side of an edge: a node, or all the nodes of a subgraph
+type dotOperand struct {
+	nodes    []int
+	subgraph bool
+}
+
+// parseOperand parses a node ID, with an optional port that is ignored, or
+// a subgraph: [subgraph [ID]] '{' statements '}'
+func (p *dotParser) parseOperand() (dotOperand, error) {
+	if p.keyword("subgraph") || p.punct("{") {
+		if p.keyword("subgraph") {
+			p.pos++
+			if p.peek().id {
+				p.pos++
+			}
+		}
+		nodes, err := p.parseBlock()
+		return dotOperand{nodes: nodes, subgraph: true}, err
+	}
+
+	id, err := p.id()
+	if err != nil {
+		return dotOperand{}, err
+	}
+	for p.punct(":") {
+		p.pos++
+		if _, err := p.id(); err != nil {
+			return dotOperand{}, err
+		}
+	}
+	return dotOperand{nodes: []int{p.builder.node(id)}}, nil
+}
+
+// parseAttributes parses any number of attribute lists: '[' (ID '=' ID [';' | ','])* ']'
+func (p *dotParser) parseAttributes() (map[string]string, error) {
+	attributes := make(map[string]string)
+	for p.punct("[") {
+		p.pos++
+		for !p.punct("]") {
+			name, err := p.id()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect("="); err != nil {
+				return nil, err
+			}
+			if attributes[name], err = p.id(); err != nil {
+				return nil, err
+			}
+			if p.punct(";") || p.punct(",") {
+				p.pos++
+			}
+		}
+		p.pos++
+	}
+	return attributes, nil
+}
+
+// placeNode sets the position of a node from its pos attribute, if it has
+// one. Graphviz positions are "x,y" with y growing upwards, optionally
+// followed by '!' to pin the node.
+func (p *dotParser) placeNode(node int, attributes map[string]string) error {
+	pos, ok := attributes["pos"]
+	if !ok {
+		return nil
+	}
+	coordinates := strings.Split(strings.TrimSuffix(strings.TrimSpace(pos), "!"), ",")
+	if len(coordinates) != 2 {
+		return fmt.Errorf("invalid position %q of node %q: expected \"x,y\"", pos, p.builder.nodes[node].ID)
+	}
+	x, errX := strconv.ParseFloat(strings.TrimSpace(coordinates[0]), 64)
+	y, errY := strconv.ParseFloat(strings.TrimSpace(coordinates[1]), 64)
+	if errX != nil || errY != nil {
+		return fmt.Errorf("invalid position %q of node %q: expected \"x,y\"", pos, p.builder.nodes[node].ID)
+	}
+	// 0-y rather than -y, which would turn 0 into -0
+	p.builder.nodes[node].X, p.builder.nodes[node].Y, p.builder.nodes[node].Placed = x, 0-y, true
+	return nil
+}
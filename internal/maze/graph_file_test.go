@@ -0,0 +1,147 @@
+package maze
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseGraphJSON(t *testing.T) {
+	m, err := ParseGraph(strings.NewReader(`{
+		"nodes": [{"id": "a", "x": 0, "y": 0}, {"id": 7, "x": 3, "y": -4}],
+		"edges": [{"from": "a", "to": 7, "weight": 2.5}, {"from": 7, "to": "c"}]
+	}`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(m.Nodes) != 3 || m.Nodes[1].ID != "7" || m.Nodes[2].ID != "c" {
+		t.Fatalf("Expected nodes a, 7 and c, got %+v", m.Nodes)
+	}
+	if m.Nodes[1].X != 3 || m.Nodes[1].Y != -4 || !m.Nodes[1].Placed || m.Nodes[2].Placed {
+		t.Errorf("Expected node 7 at (3, -4) and node c without a position, got %+v", m.Nodes)
+	}
+	if m.Placed() {
+		t.Error("Expected a graph with an unplaced node not to be placed")
+	}
+	want := []GraphEdge{{From: 0, To: 1, Weight: 2.5}, {From: 1, To: 2, Weight: 1}}
+	if len(m.Edges) != len(want) || m.Edges[0] != want[0] || m.Edges[1] != want[1] {
+		t.Errorf("Expected edges %v, got %v", want, m.Edges)
+	}
+	if m.Start != 0 || m.Goal != 2 {
+		t.Errorf("Expected the maze to run from the first to the last node, got %d to %d", m.Start, m.Goal)
+	}
+
+	tests := []struct {
+		source string
+		errMsg string
+	}{
+		{`{"nodes": [{"id": "a"}, {"id": "a"}]}`, `node "a" is listed twice`},
+		{`{"nodes": [{"id": "a", "x": 1}]}`, "needs both x and y"},
+		{`{"nodes": [{"id": true}]}`, "string or a number"},
+		{`{"nodes": [], "links": []}`, "invalid JSON graph"},
+		{`{"nodes": []}`, "no nodes"},
+		{`{"edges": [{"from": "a", "to": "a"}]}`, `edge 0 joins "a" to itself`},
+		{`{"edges": [{"from": "a", "to": "b"}, {"from": "b"}]}`, "edge 1 needs a from and a to node"},
+		{`{"edges": [{"from": "", "to": "a"}]}`, "edge 0 needs a from and a to node"},
+		{`{"edges": [{"from": "a", "to": "b"}, {"from": "b", "to": "a"}]}`, `edge 1 joins "b" and "a" again`},
+		{`{"edges": [{"from": "a", "to": "b"}, {"from": "c", "to": "d"}]}`, "not connected"},
+	}
+	for _, tt := range tests {
+		_, err := ParseGraph(strings.NewReader(tt.source))
+		if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+			t.Errorf("%s: expected error containing %q, got %v", tt.source, tt.errMsg, err)
+		}
+	}
+}
+
+func TestParseGraphDOT(t *testing.T) {
+	m, err := ParseGraph(strings.NewReader(`
+		/* A square with a
+		   diagonal */
+		strict graph "square" {
+			rankdir = LR
+			node [shape=circle]
+			a [pos="0,0!"]; b [label="B", pos="10,0"]
+			"c d" [pos="10,10"]
+			a -- b -- "c d" [weight=3, color=red] // another comment
+			subgraph cluster { e [pos=<0,10>] }
+			e -- {a "c d"}
+			b:n -> e:s;
+		}`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	ids := []string{}
+	for _, node := range m.Nodes {
+		ids = append(ids, node.ID)
+	}
+	if strings.Join(ids, ",") != "a,b,c d,e" {
+		t.Fatalf("Expected nodes a, b, c d and e, got %v", ids)
+	}
+	// Graphviz's y grows upwards, so it is flipped
+	if !m.Placed() || m.Nodes[2].X != 10 || m.Nodes[2].Y != -10 || m.Nodes[3].Y != -10 {
+		t.Errorf("Expected every node placed with y flipped, got %+v", m.Nodes)
+	}
+	want := []GraphEdge{{0, 1, 3}, {1, 2, 3}, {3, 0, 1}, {3, 2, 1}, {1, 3, 1}}
+	if len(m.Edges) != len(want) {
+		t.Fatalf("Expected edges %v, got %v", want, m.Edges)
+	}
+	for i, edge := range want {
+		if m.Edges[i] != edge {
+			t.Errorf("Expected edges %v, got %v", want, m.Edges)
+			break
+		}
+	}
+
+	// A strict graph merges repeated edges, in either direction, keeping the last weight
+	m, err = ParseGraph(strings.NewReader(`strict digraph { a -> b [weight=2]; b -> c; b -> a; a -> b [weight=4]; c -- b }`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want = []GraphEdge{{0, 1, 4}, {1, 2, 1}}
+	if len(m.Edges) != len(want) || m.Edges[0] != want[0] || m.Edges[1] != want[1] {
+		t.Errorf("Expected merged edges %v, got %v", want, m.Edges)
+	}
+
+	tests := []struct {
+		source string
+		errMsg string
+	}{
+		{`maze { a }`, "expected graph or digraph"},
+		{`graph { a -- b`, "got the end of the file"},
+		{`graph { a -- b } }`, "expected the end of the file"},
+		{`graph { a -- ; }`, `line 1: expected an ID, got ";"`},
+		{`graph { "a -- b }`, "unterminated string"},
+		{`graph { /* a -- b }`, "unterminated comment"},
+		{`graph { a -- b [weight=heavy] }`, `invalid edge weight "heavy"`},
+		{`graph { a -- b [weight=nan] }`, `invalid edge weight "nan"`},
+		{`graph { a -- b [weight="-Inf"] }`, `invalid edge weight "-Inf"`},
+		{`graph { a [pos="1"] }`, `invalid position "1" of node "a"`},
+		{`graph { a -- b; b -- a }`, `edge 1 joins "b" and "a" again`},
+		{`graph { }`, "no nodes"},
+	}
+	for _, tt := range tests {
+		_, err := ParseGraph(strings.NewReader(tt.source))
+		if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+			t.Errorf("%s: expected error containing %q, got %v", tt.source, tt.errMsg, err)
+		}
+	}
+}
+
+func TestLoadGraph(t *testing.T) {
+	for _, name := range []string{"petersen.json", "subway.dot"} {
+		m, err := LoadGraph(filepath.Join("..", "..", "examples", "graphs", name))
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		if !m.Placed() {
+			t.Errorf("%s: expected every node to have a position", name)
+		}
+	}
+
+	if _, err := LoadGraph(filepath.Join(t.TempDir(), "missing.dot")); !os.IsNotExist(err) {
+		t.Errorf("Expected a missing file error, got %v", err)
+	}
+}
@@ -0,0 +1,121 @@
+package maze
+
+import "strconv"
+
+// GraphMaze is a maze over a graph supplied by the user, such as a Voronoi
+// diagram or a subway map: named nodes, optionally placed at x/y positions,
+// joined by weighted edges. Its passages are the edges of a spanning tree,
+// and it is solved from the first node to the last one.
+type GraphMaze struct {
+	Nodes        []GraphNode
+	Edges        []GraphEdge
+	Start        int   // Index of the start node
+	Goal         int   // Index of the goal node
+	SolutionPath []int // Optional node indices from start to goal
+
+	graph *EdgeGraph
+}
+
+// GraphNode is a node of a GraphMaze
+type GraphNode struct {
+	ID     string
+	X, Y   float64 // Position, with y growing downwards as in SVG
+	Placed bool    // Whether the position was given
+}
+
+// GraphEdge is an undirected edge of a GraphMaze between two node indices.
+// Kruskal's algorithm opens the lightest edges first; the other algorithms
+// ignore the weight.
+type GraphEdge struct {
+	From, To int
+	Weight   float64
+}
+
+// NewGraphMaze creates a maze over the given nodes and edges with every
+// passage closed. The graph must be connected and may not have edges from a
+// node to itself or more than one edge between the same two nodes.
+func NewGraphMaze(nodes []GraphNode, edges []GraphEdge) (*GraphMaze, error) {
+	pairs := make([][2]int, len(edges))
+	for i, edge := range edges {
+		pairs[i] = [2]int{edge.From, edge.To}
+	}
+
+	graph, err := newEdgeGraph(len(nodes), pairs, func(node int) string { return strconv.Quote(nodes[node].ID) })
+	if err != nil {
+		return nil, err
+	}
+	for i, edge := range edges {
+		graph.SetWeight(i, edge.Weight)
+	}
+	return &GraphMaze{Nodes: nodes, Edges: edges, Start: 0, Goal: len(nodes) - 1, graph: graph}, nil
+}
+
+// GenerateGraphMaze opens the passages of a maze over a graph supplied by
+// the user. The configured algorithm must be a GraphAlgorithm.
+func (g *Generator) GenerateGraphMaze(m *GraphMaze) error {
+	return g.GenerateGraph(m.graph, m.Start)
+}
+
+// Open reports whether the edge with the given index is an open passage
+func (m *GraphMaze) Open(edge int) bool {
+	return m.graph.Open(edge)
+}
+
+// Placed reports whether every node has a position, so the maze can be drawn
+func (m *GraphMaze) Placed() bool {
+	for _, node := range m.Nodes {
+		if !node.Placed {
+			return false
+		}
+	}
+	return true
+}
+
+// Render draws the maze with a renderer that supports graph mazes, and
+// reports false if the renderer does not
+func (m *GraphMaze) Render(renderer Renderer) (string, bool) {
+	graphRenderer, ok := renderer.(GraphRenderer)
+	if !ok {
+		return "", false
+	}
+	return graphRenderer.RenderGraph(m), true
+}
+
+// Solve sets SolutionPath to the path from the start to the goal through the
+// open passages, as a list of node indices, or to nil if there is none
+func (m *GraphMaze) Solve() {
+	m.SolutionPath = m.findPath()
+}
+
+// findPath finds the path from the start to the goal by breadth-first search
+func (m *GraphMaze) findPath() []int {
+	previous := make([]int, len(m.Nodes))
+	for i := range previous {
+		previous[i] = -1
+	}
+	previous[m.Start] = m.Start
+	queue := []int{m.Start}
+	for len(queue) > 0 && previous[m.Goal] < 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for slot := 0; slot < m.graph.Degree(current); slot++ {
+			next := m.graph.Neighbor(current, slot)
+			if previous[next] < 0 && m.graph.open[m.graph.slots[current][slot]] {
+				previous[next] = current
+				queue = append(queue, next)
+			}
+		}
+	}
+	if previous[m.Goal] < 0 {
+		return nil
+	}
+
+	path := []int{m.Goal}
+	for node := m.Goal; node != m.Start; node = previous[node] {
+		path = append(path, previous[node])
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
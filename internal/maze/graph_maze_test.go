@@ -0,0 +1,145 @@
+package maze
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// createSquareGraphMaze returns a square of four placed nodes with one
+// diagonal, where the sides a-b and d-a are heavier than the other edges
+func createSquareGraphMaze(t *testing.T) *GraphMaze {
+	t.Helper()
+
+	nodes := []GraphNode{
+		{ID: "a", X: 0, Y: 0, Placed: true},
+		{ID: "b", X: 10, Y: 0, Placed: true},
+		{ID: "c", X: 10, Y: 10, Placed: true},
+		{ID: "d", X: 0, Y: 10, Placed: true},
+	}
+	edges := []GraphEdge{{0, 1, 5}, {1, 2, 1}, {2, 3, 1}, {3, 0, 4}, {0, 2, 2}}
+	m, err := NewGraphMaze(nodes, edges)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return m
+}
+
+func TestNewGraphMazeErrors(t *testing.T) {
+	nodes := []GraphNode{{ID: "a"}, {ID: "b"}}
+	tests := []struct {
+		edges  []GraphEdge
+		errMsg string
+	}{
+		// Checked before the nodes are named, so no ID is looked up out of range
+		{[]GraphEdge{{0, 1, 1}, {1, 5, 1}}, "edge 1 joins nodes 1 and 5, but there are only 2 nodes"},
+		{[]GraphEdge{{0, 1, 1}, {1, 1, 1}}, `edge 1 joins "b" to itself`},
+		{[]GraphEdge{{0, 1, 1}, {1, 0, 1}}, `edge 1 joins "b" and "a" again`},
+		{nil, "not connected"},
+	}
+	for _, tt := range tests {
+		if _, err := NewGraphMaze(nodes, tt.edges); err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+			t.Errorf("%v: expected error containing %q, got %v", tt.edges, tt.errMsg, err)
+		}
+	}
+}
+
+func TestGraphMazeWeights(t *testing.T) {
+	// Whatever the seed, Kruskal's algorithm keeps the three lightest edges
+	for _, seed := range []string{"1", "2", "3", "4", "5"} {
+		m := createSquareGraphMaze(t)
+		generator, _ := NewGeneratorWithSeedAndAlgorithm(seed, "kruskal")
+		if err := generator.GenerateGraphMaze(m); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		for i, want := range []bool{false, true, true, false, true} {
+			if m.Open(i) != want {
+				t.Errorf("Seed %s: expected edge %d open to be %v", seed, i, want)
+			}
+		}
+		checkSpanningTree(t, m.graph)
+
+		m.Solve()
+		if path := m.SolutionPath; len(path) != 3 || path[0] != 0 || path[1] != 2 || path[2] != 3 {
+			t.Errorf("Seed %s: expected the solution a, c, d, got %v", seed, path)
+		}
+	}
+
+	// Equal weights leave the order to the seed
+	graph, _ := NewEdgeGraph(10, petersenEdges)
+	for edge := range petersenEdges {
+		graph.SetWeight(edge, 7)
+	}
+	unweighted, _ := NewEdgeGraph(10, petersenEdges)
+	generator, _ := NewGeneratorWithSeedAndAlgorithm("3", "kruskal")
+	_ = generator.GenerateGraph(graph, 0)
+	generator, _ = NewGeneratorWithSeedAndAlgorithm("3", "kruskal")
+	_ = generator.GenerateGraph(unweighted, 0)
+	for edge := range petersenEdges {
+		if graph.Open(edge) != unweighted.Open(edge) {
+			t.Fatal("Expected equal weights to open the same edges as no weights")
+		}
+	}
+}
+
+func TestGraphMazeSolve(t *testing.T) {
+	m := createSquareGraphMaze(t)
+	if m.Solve(); m.SolutionPath != nil {
+		t.Errorf("Expected no path through a maze without passages, got %v", m.SolutionPath)
+	}
+
+	generator, _ := NewGeneratorWithSeedAndAlgorithm("1", "kruskal")
+	_ = generator.GenerateGraphMaze(m)
+	m.Goal = 0
+	if m.Solve(); len(m.SolutionPath) != 1 || m.SolutionPath[0] != 0 {
+		t.Errorf("Expected a path of just the start when it is the goal, got %v", m.SolutionPath)
+	}
+}
+
+func TestRenderGraph(t *testing.T) {
+	m := createSquareGraphMaze(t)
+	m.Nodes[1].ID = `say "b" \`
+	generator, _ := NewGeneratorWithSeedAndAlgorithm("1", "kruskal")
+	_ = generator.GenerateGraphMaze(m)
+	m.Solve()
+
+	dot := (&ASCIIRenderer{}).RenderGraph(m)
+	expected := `graph maze {
+  "a" [pos="0,0!", color="green"];
+  "say \"b\" \\" [pos="10,0!"];
+  "c" [pos="10,-10!"];
+  "d" [pos="0,-10!", color="red"];
+  "say \"b\" \\" -- "c";
+  "c" -- "d" [color="blue"];
+  "a" -- "c" [color="blue"];
+}
+`
+	if dot != expected {
+		t.Errorf("Expected DOT output:\n%s\ngot:\n%s", expected, dot)
+	}
+	// The output can be read back, with the same IDs and positions
+	again, err := ParseGraphDOT(strings.NewReader(dot))
+	if err != nil {
+		t.Fatalf("Failed to parse DOT output: %v", err)
+	}
+	if len(again.Nodes) != 4 || again.Nodes[1] != m.Nodes[1] || again.Nodes[2] != m.Nodes[2] || len(again.Edges) != 3 {
+		t.Errorf("Expected the DOT output to parse back into the maze, got %+v", again)
+	}
+
+	var parsed GraphJSON
+	if err := json.Unmarshal([]byte((&JSONRenderer{}).RenderGraph(m)), &parsed); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	if parsed.Shape != "graph" || parsed.Start != "a" || parsed.Goal != "d" || len(parsed.Nodes) != 4 || *parsed.Nodes[2].Y != 10 ||
+		len(parsed.Passages) != 3 || parsed.Passages[0] != (GraphPassage{From: `say "b" \`, To: "c", Weight: 1}) ||
+		strings.Join(parsed.SolutionPath, ",") != "a,c,d" {
+		t.Errorf("Unexpected JSON output: %+v", parsed)
+	}
+
+	svg := (&SVGRenderer{}).RenderGraph(m)
+	checkSVG(t, svg)
+	// The median edge of length 10 is scaled to two cells
+	if !strings.Contains(svg, `width="60" height="60"`) || !strings.Contains(svg, "M10 10L50 50L10 50") {
+		t.Errorf("Expected a 60x60 drawing with the solution along the diagonal, got:\n%s", svg)
+	}
+}
@@ -0,0 +1,239 @@
+package maze
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestGridGraph(t *testing.T) {
+	maze := createTestMaze(7, 5)
+	maze.Mask = NewMask(2, 3)
+	maze.Mask.Disable(0, 1)
+
+	graph := newGridGraph(maze)
+	if graph.CellCount() != 5 {
+		t.Fatalf("Expected 5 enabled cells, got %d", graph.CellCount())
+	}
+	for cell := 0; cell < graph.CellCount(); cell++ {
+		if got := graph.cell(graph.position(cell)); got != cell {
+			t.Errorf("Cell %d maps to %v and back to %d", cell, graph.position(cell), got)
+		}
+	}
+
+	// Cell 0 is the top-left cell: its right neighbor is masked out
+	expected := []int{-1, -1, 2, -1}
+	for slot, neighbor := range expected {
+		if got := graph.Neighbor(0, slot); got != neighbor {
+			t.Errorf("Neighbor(0, %d) = %d, expected %d", slot, got, neighbor)
+		}
+	}
+
+	// On a cylinder the left neighbor is the top-right cell instead
+	maze.Topology = TopologyCylinder
+	if got := graph.Neighbor(0, 3); got != 1 {
+		t.Errorf("Expected the left neighbor to wrap around to cell 1, got %d", got)
+	}
+	graph.Link(0, 3)
+	if maze.Grid[1][0] || maze.Grid[1][6] || maze.Grid[1][5] {
+		t.Error("Expected Link to open the wrap-around wall and the cell behind it")
+	}
+}
+
+// checkLinkedGraph verifies that a graph holds a perfect maze: every passage
+// is open from both sides, every cell is reachable, and n cells are joined by
+// n-1 passages
+func checkLinkedGraph(t *testing.T, graph *linkedGraph) {
+	t.Helper()
+
+	passages := 0
+	for cell := 0; cell < graph.CellCount(); cell++ {
+		for slot := 0; slot < graph.Degree(cell); slot++ {
+			next := graph.Neighbor(cell, slot)
+			if next >= 0 && graph.Neighbor(next, graph.reverse(cell, slot)) != cell {
+				t.Fatalf("Slot %d of cell %d leads to cell %d, which does not lead back", slot, cell, next)
+			}
+			if !graph.linked(cell, slot) {
+				continue
+			}
+			if next < 0 || !graph.linked(next, graph.reverse(cell, slot)) {
+				t.Fatalf("Passage from cell %d through slot %d is not open from the other side", cell, slot)
+			}
+			passages++
+		}
+	}
+
+	if cells := graph.CellCount(); passages/2 != cells-1 {
+		t.Fatalf("Expected %d passages for a perfect maze of %d cells, got %d", cells-1, cells, passages/2)
+	}
+	for cell, distance := range graph.distances(0) {
+		if distance < 0 {
+			t.Fatalf("Cell %d is not reachable", cell)
+		}
+	}
+}
+
+// solveLayoutMaze solves a maze of any shape for TestLayoutMazes, returning
+// its graph and whether the solution leads from the start to the goal
+func solveLayoutMaze[P comparable](maze *layoutMaze[P]) (*linkedGraph, bool) {
+	maze.Solve()
+	path := maze.SolutionPath
+	return maze.graph, len(path) > 0 && path[0] == maze.Start && path[len(path)-1] == maze.Goal
+}
+
+// layoutMazeShapes lists the shapes of maze built on layoutMaze. generate
+// creates a maze of the given size and returns the result of solveLayoutMaze.
+var layoutMazeShapes = []struct {
+	name     string
+	sizes    [][]int // Sizes to generate at
+	invalid  [][]int // Sizes that must be rejected
+	generate func(generator *Generator, size []int) (*linkedGraph, bool, error)
+}{
+	{
+		name:    "hex",
+		sizes:   [][]int{{2, 2}, {5, 7}, {10, 3}},
+		invalid: [][]int{{1, 5}, {5, 1}},
+		generate: func(generator *Generator, size []int) (*linkedGraph, bool, error) {
+			maze, err := generator.GenerateHex(size[0], size[1])
+			if err != nil {
+				return nil, false, err
+			}
+			graph, solved := solveLayoutMaze(&maze.layoutMaze)
+			return graph, solved, nil
+		},
+	},
+	{
+		name:    "polar",
+		sizes:   [][]int{{2}, {5}, {12}},
+		invalid: [][]int{{1}},
+		generate: func(generator *Generator, size []int) (*linkedGraph, bool, error) {
+			maze, err := generator.GeneratePolar(size[0])
+			if err != nil {
+				return nil, false, err
+			}
+			graph, solved := solveLayoutMaze(&maze.layoutMaze)
+			return graph, solved, nil
+		},
+	},
+	{
+		name:    "delta",
+		sizes:   [][]int{{2, 2}, {5, 7}, {10, 3}},
+		invalid: [][]int{{1, 5}, {5, 1}},
+		generate: func(generator *Generator, size []int) (*linkedGraph, bool, error) {
+			maze, err := generator.GenerateDelta(size[0], size[1])
+			if err != nil {
+				return nil, false, err
+			}
+			graph, solved := solveLayoutMaze(&maze.layoutMaze)
+			return graph, solved, nil
+		},
+	},
+	{
+		name:    "upsilon",
+		sizes:   [][]int{{2, 2}, {5, 7}, {10, 3}},
+		invalid: [][]int{{1, 5}, {5, 1}},
+		generate: func(generator *Generator, size []int) (*linkedGraph, bool, error) {
+			maze, err := generator.GenerateUpsilon(size[0], size[1])
+			if err != nil {
+				return nil, false, err
+			}
+			graph, solved := solveLayoutMaze(&maze.layoutMaze)
+			return graph, solved, nil
+		},
+	},
+	{
+		name:  "multi-level",
+		sizes: [][]int{{3, 3, 2}, {11, 7, 3}, {5, 13, 4}},
+		// TestGenerateMultiLevelErrors checks the invalid sizes and their messages
+		generate: func(generator *Generator, size []int) (*linkedGraph, bool, error) {
+			maze, err := generator.GenerateMultiLevel(size[0], size[1], size[2])
+			if err != nil {
+				return nil, false, err
+			}
+			graph, solved := solveLayoutMaze(&maze.layoutMaze)
+			return graph, solved, nil
+		},
+	},
+	{
+		name:    "cube",
+		sizes:   [][]int{{2}, {3}, {8}},
+		invalid: [][]int{{1}},
+		generate: func(generator *Generator, size []int) (*linkedGraph, bool, error) {
+			maze, err := generator.GenerateCube(size[0])
+			if err != nil {
+				return nil, false, err
+			}
+			graph, solved := solveLayoutMaze(&maze.layoutMaze)
+			return graph, solved, nil
+		},
+	},
+}
+
+func TestLayoutMazes(t *testing.T) {
+	for _, shape := range layoutMazeShapes {
+		for _, name := range GetSupportedAlgorithms() {
+			t.Run(shape.name+"/"+name, func(t *testing.T) {
+				generator, _ := NewGeneratorWithSeedAndAlgorithm("1", name)
+				if _, ok := generator.algorithm.(GraphAlgorithm); !ok {
+					_, _, err := shape.generate(generator, shape.sizes[0])
+					if err == nil || !strings.Contains(err.Error(), "algorithm "+name+" does not support") {
+						t.Errorf("Expected an error for an algorithm that needs square cells, got %v", err)
+					}
+					return
+				}
+
+				for _, size := range shape.sizes {
+					for _, seed := range []string{"1", "2", "3"} {
+						generator, _ := NewGeneratorWithSeedAndAlgorithm(seed, name)
+						graph, solved, err := shape.generate(generator, size)
+						if err != nil {
+							t.Fatalf("Size %v: unexpected error: %v", size, err)
+						}
+						checkLinkedGraph(t, graph)
+						if !solved {
+							t.Fatalf("Size %v: expected a solution path from the start to the goal", size)
+						}
+
+						again, _ := NewGeneratorWithSeedAndAlgorithm(seed, name)
+						if other, _, _ := shape.generate(again, size); !slices.Equal(other.links, graph.links) {
+							t.Fatalf("Size %v: expected the same seed to generate the same maze", size)
+						}
+					}
+				}
+			})
+		}
+
+		generator := NewGeneratorWithSeed("1")
+		for _, size := range shape.invalid {
+			if _, _, err := shape.generate(generator, size); err == nil {
+				t.Errorf("%s: expected an error for size %v", shape.name, size)
+			}
+		}
+	}
+}
+
+func TestGraphAlgorithmError(t *testing.T) {
+	generator, _ := NewGeneratorWithSeedAndAlgorithm("1", "eller")
+	_, err := generator.GenerateHex(5, 5)
+	expected := "algorithm eller does not support hexagonal mazes (supported: dfs, kruskal, wilson, prim, growing-tree, aldous-broder, hunt-and-kill)"
+	if err == nil || err.Error() != expected {
+		t.Errorf("Expected error %q, got %v", expected, err)
+	}
+}
+
+// manySlotLayout is a cellLayout of a single cell with too many slots for a linkedGraph
+type manySlotLayout struct{}
+
+func (manySlotLayout) CellCount() int          { return 1 }
+func (manySlotLayout) Degree(int) int          { return maxLinkedDegree + 1 }
+func (manySlotLayout) Neighbor(int, int) int   { return -1 }
+func (manySlotLayout) reverse(_, slot int) int { return slot }
+
+func TestLinkedGraphDegree(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected newLinkedGraph to reject a cell with more slots than it can record")
+		}
+	}()
+	newLinkedGraph(manySlotLayout{})
+}
@@ -0,0 +1,213 @@
+package maze
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// DefaultGrowingTreeStrategy is used when no cell-selection strategy is given.
+// Always extending the newest cell makes Growing Tree behave like DFS.
+const DefaultGrowingTreeStrategy = "newest"
+
+// growingTreeSelectors lists the supported cell-selection strategies
+var growingTreeSelectors = []string{"newest", "oldest", "random"}
+
+// strategyWeight pairs a cell-selection strategy with its relative weight
+type strategyWeight struct {
+	name   string
+	weight int
+}
+
+// GrowingTreeAlgorithm implements maze generation using the Growing Tree algorithm.
+// Its strategy decides which active cell is extended next: "newest" gives long
+// DFS-like corridors, "random" gives Prim-like texture, and weighted mixes such
+// as "newest:75,random:25" blend the two.
+type GrowingTreeAlgorithm struct {
+	strategy []strategyWeight
+	total    int
+}
+
+// NewGrowingTreeAlgorithm creates a Growing Tree algorithm from a strategy string.
+// An empty string selects DefaultGrowingTreeStrategy.
+func NewGrowingTreeAlgorithm(strategy string) (*GrowingTreeAlgorithm, error) {
+	if strategy == "" {
+		strategy = DefaultGrowingTreeStrategy
+	}
+
+	g := &GrowingTreeAlgorithm{}
+	for _, part := range strings.Split(strategy, ",") {
+		name, weightStr, hasWeight := strings.Cut(strings.TrimSpace(part), ":")
+		if !g.isSupportedSelector(name) {
+			return nil, fmt.Errorf("unknown growing tree strategy: %q (supported: %s)", name, strings.Join(growingTreeSelectors, ", "))
+		}
+
+		weight := 1
+		if hasWeight {
+			w, err := strconv.Atoi(weightStr)
+			if err != nil || w <= 0 || w > math.MaxInt32 {
+				return nil, fmt.Errorf("invalid weight %q for growing tree strategy %q: must be a positive integer up to %d", weightStr, name, math.MaxInt32)
+			}
+			weight = w
+		}
+
+		g.strategy = append(g.strategy, strategyWeight{name: name, weight: weight})
+		g.total += weight
+		if g.total > math.MaxInt32 {
+			return nil, fmt.Errorf("growing tree strategy %q has a total weight above %d", strategy, math.MaxInt32)
+		}
+	}
+	return g, nil
+}
+
+// String returns the normalized strategy, e.g. "newest:75,random:25"
+func (g *GrowingTreeAlgorithm) String() string {
+	parts := make([]string, len(g.strategy))
+	for i, s := range g.strategy {
+		if len(g.strategy) == 1 {
+			parts[i] = s.name
+		} else {
+			parts[i] = fmt.Sprintf("%s:%d", s.name, s.weight)
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// Generate implements the Algorithm interface using the Growing Tree algorithm
+func (g *GrowingTreeAlgorithm) Generate(maze *Maze, startRow, startCol int, rng *rand.Rand) {
+	generateOnGrid(g, maze, startRow, startCol, rng)
+}
+
+// GenerateGraph implements the GraphAlgorithm interface. It keeps a list of
+// active cells, repeatedly extends one of them into an unvisited neighbor,
+// and retires cells that have none left.
+func (g *GrowingTreeAlgorithm) GenerateGraph(graph Graph, start int, rng *rand.Rand) {
+	visited := make([]bool, graph.CellCount())
+	visited[start] = true
+	active := &activeCells{}
+	active.add(start)
+
+	for active.count > 0 {
+		index := g.selectIndex(active, rng)
+		cell := active.cells[index]
+
+		slots := g.unvisitedNeighbors(graph, visited, cell)
+		if len(slots) == 0 {
+			active.retire(index)
+			continue
+		}
+
+		slot := slots[rng.Intn(len(slots))]
+		next := graph.Neighbor(cell, slot)
+		visited[next] = true
+		graph.Link(cell, slot)
+		active.add(next)
+	}
+}
+
+// selectIndex picks the index of the next active cell according to the strategy
+func (g *GrowingTreeAlgorithm) selectIndex(active *activeCells, rng *rand.Rand) int {
+	if len(g.strategy) == 0 {
+		return len(active.cells) - 1 // The zero value uses the default "newest" strategy
+	}
+
+	name := g.strategy[0].name
+	if len(g.strategy) > 1 {
+		// Pick one of the mixed strategies in proportion to its weight
+		roll := rng.Intn(g.total)
+		for _, s := range g.strategy {
+			if roll < s.weight {
+				name = s.name
+				break
+			}
+			roll -= s.weight
+		}
+	}
+
+	switch name {
+	case "oldest":
+		return active.head
+	case "random":
+		return active.random(rng)
+	default: // newest
+		return len(active.cells) - 1
+	}
+}
+
+// activeCells is the Growing Tree's list of active cells in the order they
+// were added. Retiring a cell only marks it, so that the others keep their
+// order without being moved; retired cells are dropped once they reach
+// either end of the list, and swept out when they make up half of it.
+type activeCells struct {
+	cells   []int
+	retired []bool
+	head    int // Index of the oldest active cell
+	count   int // Number of active cells
+}
+
+// add appends a cell as the newest
+func (a *activeCells) add(cell int) {
+	a.cells = append(a.cells, cell)
+	a.retired = append(a.retired, false)
+	a.count++
+}
+
+// retire removes the active cell at index
+func (a *activeCells) retire(index int) {
+	a.retired[index] = true
+	a.count--
+	for len(a.cells) > a.head && a.retired[len(a.cells)-1] {
+		a.cells, a.retired = a.cells[:len(a.cells)-1], a.retired[:len(a.retired)-1]
+	}
+	for a.head < len(a.cells) && a.retired[a.head] {
+		a.head++
+	}
+	if len(a.cells)-a.head >= 2*a.count {
+		a.sweep()
+	}
+}
+
+// random picks an active cell uniformly. Since at least half of the cells
+// from the head on are active, it takes two tries on average.
+func (a *activeCells) random(rng *rand.Rand) int {
+	for {
+		if index := a.head + rng.Intn(len(a.cells)-a.head); !a.retired[index] {
+			return index
+		}
+	}
+}
+
+// sweep moves the active cells to the front of the list, keeping their order
+func (a *activeCells) sweep() {
+	kept := 0
+	for i := a.head; i < len(a.cells); i++ {
+		if !a.retired[i] {
+			a.cells[kept], a.retired[kept] = a.cells[i], false
+			kept++
+		}
+	}
+	a.cells, a.retired, a.head = a.cells[:kept], a.retired[:kept], 0
+}
+
+// unvisitedNeighbors returns the slots of a cell that lead to neighbors not yet part of the maze
+func (g *GrowingTreeAlgorithm) unvisitedNeighbors(graph Graph, visited []bool, cell int) []int {
+	slots := make([]int, 0, graph.Degree(cell))
+	for slot := 0; slot < graph.Degree(cell); slot++ {
+		if next := graph.Neighbor(cell, slot); next >= 0 && !visited[next] {
+			slots = append(slots, slot)
+		}
+	}
+	return slots
+}
+
+// isSupportedSelector reports whether name is a known cell-selection strategy
+func (g *GrowingTreeAlgorithm) isSupportedSelector(name string) bool {
+	for _, s := range growingTreeSelectors {
+		if name == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,179 @@
+package maze
+
+import (
+	"fmt"
+	"strings"
+)
+
+// HexMaze is a maze of flat-topped hexagonal cells arranged in columns, with
+// every odd column shifted down by half a cell. Cells are addressed by
+// Position in cell coordinates: Row and Col count cells, not grid positions.
+type HexMaze struct {
+	layoutMaze[Position]
+	Rows int
+	Cols int
+}
+
+// Hex neighbor slots, clockwise from the top
+const (
+	HexNorth = iota
+	HexNorthEast
+	HexSouthEast
+	HexSouth
+	HexSouthWest
+	HexNorthWest
+	hexSlots
+)
+
+// hexOffsets lists the neighbor in each slot as (dRow, dCol), for even and
+// odd columns; odd columns sit half a cell lower than their neighbors
+var hexOffsets = [2][hexSlots]Position{
+	{{-1, 0}, {-1, 1}, {0, 1}, {1, 0}, {0, -1}, {-1, -1}},
+	{{-1, 0}, {0, 1}, {1, 1}, {1, 0}, {1, -1}, {0, -1}},
+}
+
+// NewHexMaze creates a hexagonal maze with every wall standing, and the start
+// and goal in the top-left and bottom-right cells
+func NewHexMaze(rows, cols int) *HexMaze {
+	start, goal := Position{Row: 0, Col: 0}, Position{Row: rows - 1, Col: cols - 1}
+	return &HexMaze{
+		layoutMaze: newLayoutMaze[Position](hexLayout{rows: rows, cols: cols}, start, goal),
+		Rows:       rows,
+		Cols:       cols,
+	}
+}
+
+// GenerateHex creates a hexagonal maze of rows x cols cells. The configured
+// algorithm must be a GraphAlgorithm, since the others rely on square cells.
+func (g *Generator) GenerateHex(rows, cols int) (*HexMaze, error) {
+	if rows < 2 || cols < 2 {
+		return nil, fmt.Errorf("hexagonal maze must be at least 2x2 cells, got %dx%d", cols, rows)
+	}
+
+	maze := NewHexMaze(rows, cols)
+	if err := maze.generate(g, "hexagonal mazes", maze.Start); err != nil {
+		return nil, err
+	}
+	return maze, nil
+}
+
+// Render draws the maze with a renderer that supports hexagonal mazes, and
+// reports false if the renderer does not
+func (h *HexMaze) Render(renderer Renderer) (string, bool) {
+	hexRenderer, ok := renderer.(HexRenderer)
+	if !ok {
+		return "", false
+	}
+	return hexRenderer.RenderHex(h), true
+}
+
+// hexLayout is the cellLayout of a hexagonal grid, numbered in row-major order
+type hexLayout struct {
+	rows, cols int
+}
+
+// CellCount implements the cellLayout interface
+func (l hexLayout) CellCount() int {
+	return l.rows * l.cols
+}
+
+// Degree implements the cellLayout interface: every cell has six slots
+func (l hexLayout) Degree(int) int {
+	return hexSlots
+}
+
+// Neighbor implements the cellLayout interface
+func (l hexLayout) Neighbor(cell, slot int) int {
+	pos := l.position(cell)
+	offset := hexOffsets[pos.Col%2][slot]
+	next := Position{Row: pos.Row + offset.Row, Col: pos.Col + offset.Col}
+	if next.Row < 0 || next.Row >= l.rows || next.Col < 0 || next.Col >= l.cols {
+		return -1
+	}
+	return l.cell(next)
+}
+
+// reverse implements the cellLayout interface: neighbors face each other
+func (l hexLayout) reverse(_, slot int) int {
+	return (slot + hexSlots/2) % hexSlots
+}
+
+// cell numbers the cells in row-major order
+func (l hexLayout) cell(pos Position) int {
+	return pos.Row*l.cols + pos.Col
+}
+
+// position converts a cell number back to cell coordinates
+func (l hexLayout) position(cell int) Position {
+	return Position{Row: cell / l.cols, Col: cell % l.cols}
+}
+
+// hexGlyphs are the characters that draw a hexagonal maze as text
+type hexGlyphs struct {
+	flat, rising, falling rune // Walls: '_' along the top and bottom, '/' and '\' on the sides
+	start, goal, solution rune
+}
+
+// renderHexText draws a hexagonal maze as text. Each cell is two characters
+// wide and spans two lines plus the line it shares with the cell below:
+//
+//	 __
+//	/  \__
+//	\__/  \
+//	   \__/
+func renderHexText(m *HexMaze, glyphs hexGlyphs) string {
+	canvas := make([][]rune, 2*m.Rows+2)
+	for i := range canvas {
+		canvas[i] = []rune(strings.Repeat(" ", 3*m.Cols+1))
+	}
+
+	solutionSet := make(map[Position]bool)
+	for _, pos := range m.SolutionPath {
+		solutionSet[pos] = true
+	}
+
+	for row := 0; row < m.Rows; row++ {
+		for col := 0; col < m.Cols; col++ {
+			pos := Position{Row: row, Col: col}
+			top, left := 2*row+col%2, 3*col
+
+			// Shared walls are drawn from both sides, which agree on whether they are open
+			walls := [hexSlots]struct {
+				row, col int
+				glyph    rune
+			}{
+				{top, left + 1, glyphs.flat},
+				{top + 1, left + 3, glyphs.falling},
+				{top + 2, left + 3, glyphs.rising},
+				{top + 2, left + 1, glyphs.flat},
+				{top + 2, left, glyphs.falling},
+				{top + 1, left, glyphs.rising},
+			}
+			for slot, wall := range walls {
+				if m.Linked(pos, slot) {
+					continue
+				}
+				canvas[wall.row][wall.col] = wall.glyph
+				if wall.glyph == glyphs.flat {
+					canvas[wall.row][wall.col+1] = wall.glyph
+				}
+			}
+
+			switch {
+			case pos == m.Start:
+				canvas[top+1][left+1] = glyphs.start
+			case pos == m.Goal:
+				canvas[top+1][left+1] = glyphs.goal
+			case solutionSet[pos]:
+				canvas[top+1][left+1] = glyphs.solution
+			}
+		}
+	}
+
+	var sb strings.Builder
+	for _, line := range canvas {
+		sb.WriteString(strings.TrimRight(string(line), " "))
+		sb.WriteRune('\n')
+	}
+	return sb.String()
+}
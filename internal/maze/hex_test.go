@@ -0,0 +1,66 @@
+package maze
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestHexNeighbors(t *testing.T) {
+	maze := NewHexMaze(3, 3)
+	tests := []struct {
+		pos      Position
+		expected [hexSlots]Position
+	}{
+		// Even column: the diagonal neighbors are level with the cell and the row above
+		{Position{1, 0}, [hexSlots]Position{{0, 0}, {0, 1}, {1, 1}, {2, 0}, {1, -1}, {0, -1}}},
+		// Odd column: they are level with the cell and the row below
+		{Position{1, 1}, [hexSlots]Position{{0, 1}, {1, 2}, {2, 2}, {2, 1}, {2, 0}, {1, 0}}},
+	}
+	for _, tt := range tests {
+		for slot, expected := range tt.expected {
+			next, ok := maze.Neighbor(tt.pos, slot)
+			inside := expected.Col >= 0
+			if ok != inside || inside && next != expected {
+				t.Errorf("Neighbor(%v, %d) = %v, %v; expected %v, %v", tt.pos, slot, next, ok, expected, inside)
+			}
+		}
+	}
+}
+
+func TestRenderHex(t *testing.T) {
+	// Two cells side by side, joined through the wall between them
+	maze := NewHexMaze(1, 2)
+	maze.link(Position{Row: 0, Col: 0}, HexSouthEast)
+	maze.Solve()
+	if len(maze.SolutionPath) != 2 {
+		t.Fatalf("Expected a two-cell solution, got %v", maze.SolutionPath)
+	}
+
+	ascii := (&ASCIIRenderer{}).RenderHex(maze)
+	expected := " __\n/● \\__\n\\__ ○ \\\n   \\__/\n"
+	if ascii != expected {
+		t.Errorf("Expected ASCII output:\n%s\ngot:\n%s", expected, ascii)
+	}
+	if output, ok := maze.Render(&ASCIIRenderer{}); !ok || output != ascii {
+		t.Errorf("Expected Render to draw the maze with the renderer, got %v:\n%s", ok, output)
+	}
+	if unicode := (&UnicodeRenderer{}).RenderHex(maze); !strings.Contains(unicode, "╱◉ ╲") {
+		t.Errorf("Expected box-drawing walls and markers, got:\n%s", unicode)
+	}
+
+	var parsed HexJSON
+	if err := json.Unmarshal([]byte((&JSONRenderer{}).RenderHex(maze)), &parsed); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	if parsed.Shape != "hex" || parsed.Rows != 1 || parsed.Cols != 2 || len(parsed.Passages) != 1 ||
+		parsed.Passages[0] != (Passage{From: Position{0, 0}, To: Position{0, 1}}) {
+		t.Errorf("Unexpected JSON output: %+v", parsed)
+	}
+
+	svg := (&SVGRenderer{}).RenderHex(maze)
+	// Each hexagon has six sides; the one between the cells is open
+	if segments := strings.Count(checkSVG(t, svg), "M"); segments != 10 {
+		t.Errorf("Expected 10 wall segments, got %d", segments)
+	}
+}
@@ -0,0 +1,68 @@
+package maze
+
+import "math/rand"
+
+// HuntAndKillAlgorithm implements maze generation using the Hunt-and-Kill algorithm.
+// It carves long DFS-like corridors, but instead of backtracking with a stack it
+// scans the grid for an unvisited cell next to the maze whenever it gets stuck.
+type HuntAndKillAlgorithm struct{}
+
+// Generate implements the Algorithm interface using the Hunt-and-Kill algorithm
+func (h *HuntAndKillAlgorithm) Generate(maze *Maze, startRow, startCol int, rng *rand.Rand) {
+	generateOnGrid(h, maze, startRow, startCol, rng)
+}
+
+// GenerateGraph implements the GraphAlgorithm interface. It alternates
+// between a random walk through unvisited cells (kill) and a scan of the
+// cells in order for the next place to continue from (hunt).
+func (h *HuntAndKillAlgorithm) GenerateGraph(graph Graph, start int, rng *rand.Rand) {
+	visited := make([]bool, graph.CellCount())
+	visited[start] = true
+	current := start
+
+	// Cells before hunt have all been visited, so hunting can skip them
+	hunt := 0
+
+	for {
+		// Kill: walk into random unvisited neighbors until there are none
+		if unvisited := h.neighbors(graph, visited, current, false); len(unvisited) > 0 {
+			slot := unvisited[rng.Intn(len(unvisited))]
+			graph.Link(current, slot)
+			current = graph.Neighbor(current, slot)
+			visited[current] = true
+			continue
+		}
+
+		// Hunt: find the first unvisited cell that borders the maze
+		for hunt < len(visited) && visited[hunt] {
+			hunt++
+		}
+		found := false
+		for cell := hunt; cell < len(visited) && !found; cell++ {
+			if visited[cell] {
+				continue
+			}
+			if mazeSlots := h.neighbors(graph, visited, cell, true); len(mazeSlots) > 0 {
+				graph.Link(cell, mazeSlots[rng.Intn(len(mazeSlots))])
+				visited[cell] = true
+				current = cell
+				found = true
+			}
+		}
+
+		if !found {
+			return
+		}
+	}
+}
+
+// neighbors returns the slots of a cell that lead to visited (or unvisited) neighbors
+func (h *HuntAndKillAlgorithm) neighbors(graph Graph, visited []bool, cell int, wantVisited bool) []int {
+	slots := make([]int, 0, graph.Degree(cell))
+	for slot := 0; slot < graph.Degree(cell); slot++ {
+		if next := graph.Neighbor(cell, slot); next >= 0 && visited[next] == wantVisited {
+			slots = append(slots, slot)
+		}
+	}
+	return slots
+}
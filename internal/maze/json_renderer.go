@@ -17,6 +17,36 @@ type JSON struct {
 	Start        Position   `json:"start"`
 	Goal         Position   `json:"goal"`
 	SolutionPath []Position `json:"solution_path,omitempty"`
+	Openings     []Position `json:"openings,omitempty"`
+	// DisabledCells lists the cells outside the mask, in grid coordinates
+	DisabledCells []Position `json:"disabled_cells,omitempty"`
+	Rooms         []RoomJSON `json:"rooms,omitempty"`
+	// Topology is "cylinder" or "torus" when passages wrap around; the
+	// matching border positions in the grid are open where they do
+	Topology string `json:"topology,omitempty"`
+	// Crossings lists the cells of a weave maze where one corridor passes
+	// over another; their four walls are open in the grid
+	Crossings []CrossingJSON `json:"crossings,omitempty"`
+}
+
+// CrossingJSON is a crossing of a weave maze, in grid coordinates
+type CrossingJSON struct {
+	PositionJSON
+	Over string `json:"over"` // The corridor on top: "horizontal" or "vertical"
+}
+
+// RoomJSON is a room of the maze with the doors through its wall, given like
+// its corners as lowercase row and column keys
+type RoomJSON struct {
+	Rect
+	Doors []PositionJSON `json:"doors"`
+}
+
+// PositionJSON is a position in grid coordinates with lowercase keys, for the
+// objects whose other keys are lowercase too
+type PositionJSON struct {
+	Row int `json:"row"`
+	Col int `json:"col"`
 }
 
 // Render generates a JSON representation of the maze.
@@ -35,6 +65,370 @@ func (r *JSONRenderer) Render(m *Maze) string {
 			Col: m.GoalCol,
 		},
 		SolutionPath: m.SolutionPath,
+		Openings:     m.Openings,
+	}
+	for _, room := range m.Rooms {
+		roomJSON := RoomJSON{Rect: room.Rect, Doors: make([]PositionJSON, len(room.Doors))}
+		for i, door := range room.Doors {
+			roomJSON.Doors[i] = PositionJSON(door)
+		}
+		mazeJSON.Rooms = append(mazeJSON.Rooms, roomJSON)
+	}
+	if m.Topology != TopologyPlane {
+		mazeJSON.Topology = m.Topology.String()
+	}
+
+	if len(m.Crossings) > 0 {
+		for row := 1; row < m.Height-1; row += 2 {
+			for col := 1; col < m.Width-1; col += 2 {
+				if crossing, ok := m.Crossings[Position{Row: row, Col: col}]; ok {
+					mazeJSON.Crossings = append(mazeJSON.Crossings, CrossingJSON{PositionJSON: PositionJSON{Row: row, Col: col}, Over: crossing.String()})
+				}
+			}
+		}
+	}
+
+	if m.Mask != nil {
+		for row := 1; row < m.Height-1; row += 2 {
+			for col := 1; col < m.Width-1; col += 2 {
+				if !m.cellEnabled(row, col) {
+					mazeJSON.DisabledCells = append(mazeJSON.DisabledCells, Position{Row: row, Col: col})
+				}
+			}
+		}
+	}
+
+	jsonBytes, err := json.MarshalIndent(mazeJSON, "", "  ")
+	if err != nil {
+		return "{\"error\": \"failed to marshal maze to JSON\"}"
+	}
+
+	return string(jsonBytes)
+}
+
+// HexJSON represents the JSON structure for hexagonal maze output. Cells are
+// given as row and column, with odd columns shifted half a cell down.
+type HexJSON struct {
+	Shape        string     `json:"shape"` // Always "hex"
+	Rows         int        `json:"rows"`
+	Cols         int        `json:"cols"`
+	Start        Position   `json:"start"`
+	Goal         Position   `json:"goal"`
+	Passages     []Passage  `json:"passages"`
+	SolutionPath []Position `json:"solution_path,omitempty"`
+}
+
+// Passage is an open passage between two neighboring cells
+type Passage struct {
+	From Position `json:"from"`
+	To   Position `json:"to"`
+}
+
+// RenderHex implements the HexRenderer interface, listing every open passage once
+func (r *JSONRenderer) RenderHex(m *HexMaze) string {
+	mazeJSON := HexJSON{
+		Shape:        "hex",
+		Rows:         m.Rows,
+		Cols:         m.Cols,
+		Start:        m.Start,
+		Goal:         m.Goal,
+		Passages:     make([]Passage, 0),
+		SolutionPath: m.SolutionPath,
+	}
+	for row := 0; row < m.Rows; row++ {
+		for col := 0; col < m.Cols; col++ {
+			pos := Position{Row: row, Col: col}
+			for _, slot := range []int{HexNorthEast, HexSouthEast, HexSouth} {
+				if next, ok := m.Neighbor(pos, slot); ok && m.Linked(pos, slot) {
+					mazeJSON.Passages = append(mazeJSON.Passages, Passage{From: pos, To: next})
+				}
+			}
+		}
+	}
+
+	jsonBytes, err := json.MarshalIndent(mazeJSON, "", "  ")
+	if err != nil {
+		return "{\"error\": \"failed to marshal maze to JSON\"}"
+	}
+
+	return string(jsonBytes)
+}
+
+// PolarJSON represents the JSON structure for circular maze output. Cells are
+// given with Row as the ring, 0 being the centre, and Col as the index of the
+// cell in its ring, clockwise from the top.
+type PolarJSON struct {
+	Shape        string     `json:"shape"` // Always "polar"
+	Rings        int        `json:"rings"`
+	RingSizes    []int      `json:"ring_sizes"`
+	Start        Position   `json:"start"`
+	Goal         Position   `json:"goal"`
+	Passages     []Passage  `json:"passages"`
+	SolutionPath []Position `json:"solution_path,omitempty"`
+}
+
+// RenderPolar implements the PolarRenderer interface, listing every open passage once
+func (r *JSONRenderer) RenderPolar(m *PolarMaze) string {
+	mazeJSON := PolarJSON{
+		Shape:        "polar",
+		Rings:        m.Rings,
+		RingSizes:    m.RingSizes,
+		Start:        m.Start,
+		Goal:         m.Goal,
+		Passages:     make([]Passage, 0),
+		SolutionPath: m.SolutionPath,
+	}
+	for ring := 0; ring < m.Rings; ring++ {
+		for col := 0; col < m.RingSizes[ring]; col++ {
+			pos := Position{Row: ring, Col: col}
+			for slot := PolarClockwise; slot < m.Degree(pos); slot++ {
+				if slot == PolarCounterClockwise {
+					continue // Listed as the clockwise passage of the other cell
+				}
+				if next, ok := m.Neighbor(pos, slot); ok && m.Linked(pos, slot) {
+					mazeJSON.Passages = append(mazeJSON.Passages, Passage{From: pos, To: next})
+				}
+			}
+		}
+	}
+
+	jsonBytes, err := json.MarshalIndent(mazeJSON, "", "  ")
+	if err != nil {
+		return "{\"error\": \"failed to marshal maze to JSON\"}"
+	}
+
+	return string(jsonBytes)
+}
+
+// DeltaJSON represents the JSON structure for triangular maze output. Cells
+// are given as row and column; cell (row, col) points up when row+col is even.
+type DeltaJSON struct {
+	Shape        string     `json:"shape"` // Always "delta"
+	Rows         int        `json:"rows"`
+	Cols         int        `json:"cols"`
+	Start        Position   `json:"start"`
+	Goal         Position   `json:"goal"`
+	Passages     []Passage  `json:"passages"`
+	SolutionPath []Position `json:"solution_path,omitempty"`
+}
+
+// RenderDelta implements the DeltaRenderer interface, listing every open passage once
+func (r *JSONRenderer) RenderDelta(m *DeltaMaze) string {
+	mazeJSON := DeltaJSON{
+		Shape:        "delta",
+		Rows:         m.Rows,
+		Cols:         m.Cols,
+		Start:        m.Start,
+		Goal:         m.Goal,
+		Passages:     make([]Passage, 0),
+		SolutionPath: m.SolutionPath,
+	}
+	for row := 0; row < m.Rows; row++ {
+		for col := 0; col < m.Cols; col++ {
+			pos := Position{Row: row, Col: col}
+			slots := []int{DeltaEast}
+			if m.PointsUp(pos) {
+				slots = append(slots, DeltaBase)
+			}
+			for _, slot := range slots {
+				if next, ok := m.Neighbor(pos, slot); ok && m.Linked(pos, slot) {
+					mazeJSON.Passages = append(mazeJSON.Passages, Passage{From: pos, To: next})
+				}
+			}
+		}
+	}
+
+	jsonBytes, err := json.MarshalIndent(mazeJSON, "", "  ")
+	if err != nil {
+		return "{\"error\": \"failed to marshal maze to JSON\"}"
+	}
+
+	return string(jsonBytes)
+}
+
+// UpsilonJSON represents the JSON structure for upsilon maze output. Cells
+// are given as row and column; cell (row, col) is an octagon when row+col is
+// even and a square otherwise.
+type UpsilonJSON struct {
+	Shape        string     `json:"shape"` // Always "upsilon"
+	Rows         int        `json:"rows"`
+	Cols         int        `json:"cols"`
+	Start        Position   `json:"start"`
+	Goal         Position   `json:"goal"`
+	Passages     []Passage  `json:"passages"`
+	SolutionPath []Position `json:"solution_path,omitempty"`
+}
+
+// RenderUpsilon implements the UpsilonRenderer interface, listing every open passage once
+func (r *JSONRenderer) RenderUpsilon(m *UpsilonMaze) string {
+	mazeJSON := UpsilonJSON{
+		Shape:        "upsilon",
+		Rows:         m.Rows,
+		Cols:         m.Cols,
+		Start:        m.Start,
+		Goal:         m.Goal,
+		Passages:     make([]Passage, 0),
+		SolutionPath: m.SolutionPath,
+	}
+	for row := 0; row < m.Rows; row++ {
+		for col := 0; col < m.Cols; col++ {
+			pos := Position{Row: row, Col: col}
+			for _, slot := range []int{UpsilonEast, UpsilonSouth, UpsilonSouthEast, UpsilonSouthWest} {
+				if next, ok := m.Neighbor(pos, slot); ok && m.Linked(pos, slot) {
+					mazeJSON.Passages = append(mazeJSON.Passages, Passage{From: pos, To: next})
+				}
+			}
+		}
+	}
+
+	jsonBytes, err := json.MarshalIndent(mazeJSON, "", "  ")
+	if err != nil {
+		return "{\"error\": \"failed to marshal maze to JSON\"}"
+	}
+
+	return string(jsonBytes)
+}
+
+// MultiLevelJSON represents the JSON structure for multi-level maze output.
+// Floors holds the grid of each floor from the bottom up, so the maze has a
+// depth as well as a width and height.
+type MultiLevelJSON struct {
+	Width  int        `json:"width"`
+	Height int        `json:"height"`
+	Depth  int        `json:"depth"`
+	Floors [][][]bool `json:"floors"`
+	// Stairs lists the cells with stairs up to the cell above them
+	Stairs       []Location `json:"stairs"`
+	Start        Location   `json:"start"`
+	Goal         Location   `json:"goal"`
+	SolutionPath []Location `json:"solution_path,omitempty"`
+}
+
+// RenderMultiLevel implements the MultiLevelRenderer interface
+func (r *JSONRenderer) RenderMultiLevel(m *MultiLevelMaze, _ bool) string {
+	mazeJSON := MultiLevelJSON{
+		Width:        m.Width,
+		Height:       m.Height,
+		Depth:        m.Depth,
+		Floors:       make([][][]bool, m.Depth),
+		Stairs:       make([]Location, 0),
+		Start:        m.Start,
+		Goal:         m.Goal,
+		SolutionPath: m.SolutionPath,
+	}
+	for floor := 0; floor < m.Depth; floor++ {
+		mazeJSON.Floors[floor] = m.FloorGrid(floor)
+		for row := 1; row < m.Height-1; row += 2 {
+			for col := 1; col < m.Width-1; col += 2 {
+				if loc := (Location{Floor: floor, Row: row, Col: col}); m.Linked(loc, LevelUp) {
+					mazeJSON.Stairs = append(mazeJSON.Stairs, loc)
+				}
+			}
+		}
+	}
+
+	jsonBytes, err := json.MarshalIndent(mazeJSON, "", "  ")
+	if err != nil {
+		return "{\"error\": \"failed to marshal maze to JSON\"}"
+	}
+
+	return string(jsonBytes)
+}
+
+// CubeJSON represents the JSON structure for cube maze output. Cells are given
+// as face, row and column, with faces numbered front, right, back, left, top,
+// bottom from 0; passages may join cells on different faces.
+type CubeJSON struct {
+	Shape        string         `json:"shape"` // Always "cube"
+	Size         int            `json:"size"`
+	Start        FacePosition   `json:"start"`
+	Goal         FacePosition   `json:"goal"`
+	Passages     []CubePassage  `json:"passages"`
+	SolutionPath []FacePosition `json:"solution_path,omitempty"`
+}
+
+// CubePassage is an open passage between two neighboring cells of a cube maze
+type CubePassage struct {
+	From FacePosition `json:"from"`
+	To   FacePosition `json:"to"`
+}
+
+// RenderCube implements the CubeRenderer interface, listing every open passage once
+func (r *JSONRenderer) RenderCube(m *CubeMaze) string {
+	mazeJSON := CubeJSON{
+		Shape:        "cube",
+		Size:         m.Size,
+		Start:        m.Start,
+		Goal:         m.Goal,
+		Passages:     make([]CubePassage, 0),
+		SolutionPath: m.SolutionPath,
+	}
+	for cell := 0; cell < m.layout.CellCount(); cell++ {
+		for slot := 0; slot < cubeSlots; slot++ {
+			// Each passage is listed from the lower-numbered of its two cells
+			if next := m.layout.Neighbor(cell, slot); next > cell && m.graph.linked(cell, slot) {
+				mazeJSON.Passages = append(mazeJSON.Passages, CubePassage{From: m.layout.position(cell), To: m.layout.position(next)})
+			}
+		}
+	}
+
+	jsonBytes, err := json.MarshalIndent(mazeJSON, "", "  ")
+	if err != nil {
+		return "{\"error\": \"failed to marshal maze to JSON\"}"
+	}
+
+	return string(jsonBytes)
+}
+
+// GraphJSON represents the JSON structure for the output of a maze over a
+// graph supplied by the user. Nodes are referred to by their IDs.
+type GraphJSON struct {
+	Shape        string          `json:"shape"` // Always "graph"
+	Nodes        []GraphNodeJSON `json:"nodes"`
+	Start        string          `json:"start"`
+	Goal         string          `json:"goal"`
+	Passages     []GraphPassage  `json:"passages"`
+	SolutionPath []string        `json:"solution_path,omitempty"`
+}
+
+// GraphNodeJSON is a node of a graph maze, with its position if it has one
+type GraphNodeJSON struct {
+	ID string   `json:"id"`
+	X  *float64 `json:"x,omitempty"`
+	Y  *float64 `json:"y,omitempty"`
+}
+
+// GraphPassage is an open passage between two nodes of a graph maze
+type GraphPassage struct {
+	From   string  `json:"from"`
+	To     string  `json:"to"`
+	Weight float64 `json:"weight"`
+}
+
+// RenderGraph implements the GraphRenderer interface, listing the open
+// passages in the order of the edges they were given as
+func (r *JSONRenderer) RenderGraph(m *GraphMaze) string {
+	mazeJSON := GraphJSON{
+		Shape:    "graph",
+		Nodes:    make([]GraphNodeJSON, len(m.Nodes)),
+		Start:    m.Nodes[m.Start].ID,
+		Goal:     m.Nodes[m.Goal].ID,
+		Passages: make([]GraphPassage, 0, len(m.Nodes)-1),
+	}
+	for i, node := range m.Nodes {
+		mazeJSON.Nodes[i].ID = node.ID
+		if node.Placed {
+			x, y := node.X, node.Y
+			mazeJSON.Nodes[i].X, mazeJSON.Nodes[i].Y = &x, &y
+		}
+	}
+	for i, edge := range m.Edges {
+		if m.Open(i) {
+			mazeJSON.Passages = append(mazeJSON.Passages, GraphPassage{From: m.Nodes[edge.From].ID, To: m.Nodes[edge.To].ID, Weight: edge.Weight})
+		}
+	}
+	for _, node := range m.SolutionPath {
+		mazeJSON.SolutionPath = append(mazeJSON.SolutionPath, m.Nodes[node].ID)
 	}
 
 	jsonBytes, err := json.MarshalIndent(mazeJSON, "", "  ")
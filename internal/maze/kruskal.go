@@ -1,16 +1,13 @@
 package maze
 
-import "math/rand"
+import (
+	"math/rand"
+	"sort"
+)
 
 // KruskalAlgorithm implements maze generation using Kruskal's algorithm
 type KruskalAlgorithm struct{}
 
-// Edge represents a connection between two cells in the maze
-type Edge struct {
-	fromRow, fromCol int
-	toRow, toCol     int
-}
-
 // UnionFind data structure for tracking connected components
 type UnionFind struct {
 	parent []int
@@ -56,62 +53,65 @@ func (uf *UnionFind) Union(x, y int) bool {
 	return true
 }
 
+// cellSlot is a possible passage of a Graph: a cell and one of its neighbor slots
+type cellSlot struct {
+	cell, slot int32
+}
+
 // Generate implements the Algorithm interface using Kruskal's algorithm
 func (k *KruskalAlgorithm) Generate(maze *Maze, startRow, startCol int, rng *rand.Rand) {
+	generateOnGrid(k, maze, startRow, startCol, rng)
+}
+
+// GenerateGraph implements the GraphAlgorithm interface using Kruskal's algorithm
+func (k *KruskalAlgorithm) GenerateGraph(graph Graph, start int, rng *rand.Rand) {
 	// Create all possible edges between adjacent cells
-	edges := k.createEdges(maze)
+	edges := k.createEdges(graph)
 
 	// Shuffle edges for randomness
 	k.shuffleEdges(edges, rng)
 
+	// Weighted graphs get their lightest spanning tree, with ties broken by the shuffle
+	if weighted, ok := graph.(WeightedGraph); ok {
+		sort.SliceStable(edges, func(i, j int) bool {
+			return weighted.Weight(int(edges[i].cell), int(edges[i].slot)) < weighted.Weight(int(edges[j].cell), int(edges[j].slot))
+		})
+	}
+
 	// Create union-find structure for cells
-	cellCount := ((maze.Height - 1) / 2) * ((maze.Width - 1) / 2)
-	uf := NewUnionFind(cellCount)
+	uf := NewUnionFind(graph.CellCount())
 
 	// Process edges and connect components
 	for _, edge := range edges {
-		cell1 := k.cellToIndex(maze, edge.fromRow, edge.fromCol)
-		cell2 := k.cellToIndex(maze, edge.toRow, edge.toCol)
+		cell1 := int(edge.cell)
+		cell2 := graph.Neighbor(cell1, int(edge.slot))
 
 		// If cells are in different components, connect them
 		if uf.Union(cell1, cell2) {
-			// Mark both cells as paths
-			maze.Grid[edge.fromRow][edge.fromCol] = false
-			maze.Grid[edge.toRow][edge.toCol] = false
-
-			// Remove wall between cells
-			wallRow := (edge.fromRow + edge.toRow) / 2
-			wallCol := (edge.fromCol + edge.toCol) / 2
-			maze.Grid[wallRow][wallCol] = false
+			graph.Link(cell1, int(edge.slot))
 		}
 	}
 }
 
-// createEdges generates all possible edges between adjacent cells
-func (k *KruskalAlgorithm) createEdges(maze *Maze) []Edge {
-	var edges []Edge
-
-	// Create edges between horizontally adjacent cells
-	for row := 1; row < maze.Height-1; row += 2 {
-		for col := 1; col < maze.Width-3; col += 2 {
-			edges = append(edges, Edge{
-				fromRow: row,
-				fromCol: col,
-				toRow:   row,
-				toCol:   col + 2,
-			})
-		}
-	}
-
-	// Create edges between vertically adjacent cells
-	for row := 1; row < maze.Height-3; row += 2 {
-		for col := 1; col < maze.Width-1; col += 2 {
-			edges = append(edges, Edge{
-				fromRow: row,
-				fromCol: col,
-				toRow:   row + 2,
-				toCol:   col,
-			})
+// createEdges lists every edge of the graph once, from the lower-numbered
+// cell, slot by slot: on a rectangular grid all horizontal edges come first,
+// then all vertical ones
+func (k *KruskalAlgorithm) createEdges(graph Graph) []cellSlot {
+	var edges []cellSlot
+
+	for slot, more := 0, true; more; slot++ {
+		more = false
+		for cell := 0; cell < graph.CellCount(); cell++ {
+			if slot >= graph.Degree(cell) {
+				continue
+			}
+			more = true
+			if graph.Neighbor(cell, slot) > cell {
+				edges = append(edges, cellSlot{
+					cell: int32(cell), // #nosec G115 - cell counts fit in int32
+					slot: int32(slot), // #nosec G115 - degrees fit in int32
+				})
+			}
 		}
 	}
 
@@ -119,17 +119,9 @@ func (k *KruskalAlgorithm) createEdges(maze *Maze) []Edge {
 }
 
 // shuffleEdges randomizes the order of edges
-func (k *KruskalAlgorithm) shuffleEdges(edges []Edge, rng *rand.Rand) {
+func (k *KruskalAlgorithm) shuffleEdges(edges []cellSlot, rng *rand.Rand) {
 	for i := len(edges) - 1; i > 0; i-- {
 		j := rng.Intn(i + 1)
 		edges[i], edges[j] = edges[j], edges[i]
 	}
 }
-
-// cellToIndex converts cell coordinates to a unique index
-func (k *KruskalAlgorithm) cellToIndex(maze *Maze, row, col int) int {
-	cellRow := (row - 1) / 2
-	cellCol := (col - 1) / 2
-	cellsPerRow := (maze.Width - 1) / 2
-	return cellRow*cellsPerRow + cellCol
-}
@@ -0,0 +1,326 @@
+package maze
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"image/color"
+	"image/png"
+	"io"
+	"math/rand"
+	"os"
+	"strings"
+)
+
+// pngSignature is the magic number at the start of every PNG file
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// Mask marks which cells of a maze can be used, so that the passable area
+// follows a silhouette such as a heart or a country outline. It is indexed in
+// cell coordinates: mask cell (r, c) is grid position (2r+1, 2c+1).
+type Mask struct {
+	Rows, Cols int
+	disabled   [][]bool
+}
+
+// NewMask creates a mask of rows x cols cells with every cell enabled
+func NewMask(rows, cols int) *Mask {
+	disabled := make([][]bool, rows)
+	for i := range disabled {
+		disabled[i] = make([]bool, cols)
+	}
+	return &Mask{Rows: rows, Cols: cols, disabled: disabled}
+}
+
+// LoadMask reads a mask from a file, which is either a PNG image (see
+// ParseMaskPNG) or a text file (see ParseMaskText)
+func LoadMask(path string) (*Mask, error) {
+	file, err := os.Open(path) // #nosec G304 - the mask path is chosen by the user
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	reader := bufio.NewReader(file)
+	if signature, _ := reader.Peek(len(pngSignature)); bytes.Equal(signature, pngSignature) {
+		return ParseMaskPNG(reader)
+	}
+	return ParseMaskText(reader)
+}
+
+// ParseMaskText parses a text mask with one line per row of cells, where 'X'
+// (or 'x') disables a cell and any other character enables it. Shorter lines
+// are padded with enabled cells and trailing blank lines are ignored.
+func ParseMaskText(r io.Reader) (*Mask, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	cols := 0
+	for _, line := range lines {
+		if n := len([]rune(line)); n > cols {
+			cols = n
+		}
+	}
+
+	mask := NewMask(len(lines), cols)
+	for row, line := range lines {
+		for col, char := range []rune(line) {
+			mask.disabled[row][col] = char == 'X' || char == 'x'
+		}
+	}
+	return mask, mask.validate()
+}
+
+// ParseMaskPNG parses a black-and-white PNG mask with one pixel per cell.
+// Dark or transparent pixels disable a cell and light pixels enable it.
+func ParseMaskPNG(r io.Reader) (*Mask, error) {
+	img, err := png.Decode(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid PNG mask: %w", err)
+	}
+
+	bounds := img.Bounds()
+	mask := NewMask(bounds.Dy(), bounds.Dx())
+	for row := 0; row < mask.Rows; row++ {
+		for col := 0; col < mask.Cols; col++ {
+			pixel := img.At(bounds.Min.X+col, bounds.Min.Y+row)
+			_, _, _, alpha := pixel.RGBA()
+			gray := color.Gray16Model.Convert(pixel).(color.Gray16)
+			mask.disabled[row][col] = alpha < 0x8000 || gray.Y < 0x8000
+		}
+	}
+	return mask, mask.validate()
+}
+
+// Disable removes the cell at (row, col), in cell coordinates, from the maze
+func (m *Mask) Disable(row, col int) {
+	m.disabled[row][col] = true
+}
+
+// disableRect removes every cell of a rectangle given in grid coordinates
+func (m *Mask) disableRect(rect Rect) {
+	for row := rect.Top; row <= rect.Bottom; row += 2 {
+		for col := rect.Left; col <= rect.Right; col += 2 {
+			m.Disable((row-1)/2, (col-1)/2)
+		}
+	}
+}
+
+// clone returns an independent copy of the mask
+func (m *Mask) clone() *Mask {
+	clone := NewMask(m.Rows, m.Cols)
+	for row := range m.disabled {
+		copy(clone.disabled[row], m.disabled[row])
+	}
+	return clone
+}
+
+// Enabled reports whether the cell at (row, col), in cell coordinates, is part of the maze
+func (m *Mask) Enabled(row, col int) bool {
+	return row >= 0 && row < m.Rows && col >= 0 && col < m.Cols && !m.disabled[row][col]
+}
+
+// GridWidth returns the width of the maze grid that the mask covers
+func (m *Mask) GridWidth() int {
+	return 2*m.Cols + 1
+}
+
+// GridHeight returns the height of the maze grid that the mask covers
+func (m *Mask) GridHeight() int {
+	return 2*m.Rows + 1
+}
+
+// validate checks that the enabled cells form a single connected region with
+// room for both a start and a goal, so that a perfect maze can span all of them
+func (m *Mask) validate() error {
+	var first *[2]int
+	enabled := 0
+	for row := 0; row < m.Rows; row++ {
+		for col := 0; col < m.Cols; col++ {
+			if m.Enabled(row, col) {
+				if first == nil {
+					first = &[2]int{row, col}
+				}
+				enabled++
+			}
+		}
+	}
+	if enabled < 2 {
+		return fmt.Errorf("mask must enable at least 2 cells, got %d", enabled)
+	}
+
+	// Flood fill from the first enabled cell
+	visited := make([][]bool, m.Rows)
+	for i := range visited {
+		visited[i] = make([]bool, m.Cols)
+	}
+	visited[first[0]][first[1]] = true
+	stack := [][2]int{*first}
+	reached := 0
+	for len(stack) > 0 {
+		cell := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		reached++
+		for _, dir := range [][2]int{{-1, 0}, {0, 1}, {1, 0}, {0, -1}} {
+			row, col := cell[0]+dir[0], cell[1]+dir[1]
+			if m.Enabled(row, col) && !visited[row][col] {
+				visited[row][col] = true
+				stack = append(stack, [2]int{row, col})
+			}
+		}
+	}
+	if reached != enabled {
+		return fmt.Errorf("mask enabled cells must form a single connected region (%d of %d cells reachable)", reached, enabled)
+	}
+	return nil
+}
+
+// cellEnabled reports whether the cell at grid position (row, col) is part of
+// the maze; every cell is enabled when the maze has no mask. While rooms and
+// obstacles are laid out, the layout mask takes the place of the maze's mask.
+func (m *Maze) cellEnabled(row, col int) bool {
+	mask := m.Mask
+	if m.layout != nil {
+		mask = m.layout
+	}
+	return mask == nil || mask.Enabled((row-1)/2, (col-1)/2)
+}
+
+// masked reports whether some cells may be left out of the maze
+func (m *Maze) masked() bool {
+	return m.Mask != nil || m.layout != nil
+}
+
+// enabledCellCount returns the number of cells that are part of the maze
+func (m *Maze) enabledCellCount() int {
+	count := 0
+	for row := 1; row < m.Height-1; row += 2 {
+		for col := 1; col < m.Width-1; col += 2 {
+			if m.cellEnabled(row, col) {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// outsideMask reports whether the grid position (row, col) touches no enabled
+// cell, i.e. lies entirely outside the masked area. Renderers leave it blank.
+func (m *Maze) outsideMask(row, col int) bool {
+	if m.Mask == nil {
+		return false
+	}
+	// An odd coordinate belongs to one cell, an even one sits between two
+	for r := row - 1 + row%2; r <= row+1-row%2; r += 2 {
+		for c := col - 1 + col%2; c <= col+1-col%2; c += 2 {
+			if m.Mask.Enabled((r-1)/2, (c-1)/2) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// maskCells returns the grid positions of the first and last enabled cells in
+// row-major order, used as the default start and goal of a masked maze
+func (m *Mask) maskCells() (Position, Position) {
+	var first, last Position
+	found := false
+	for row := 0; row < m.Rows; row++ {
+		for col := 0; col < m.Cols; col++ {
+			if m.Enabled(row, col) {
+				if !found {
+					first = Position{Row: 2*row + 1, Col: 2*col + 1}
+					found = true
+				}
+				last = Position{Row: 2*row + 1, Col: 2*col + 1}
+			}
+		}
+	}
+	return first, last
+}
+
+// applyMask walls off the disabled cells of a generated maze and then joins
+// any enabled cells that were cut apart, so that the enabled region always
+// holds a single perfect maze. Algorithms that already respect the mask leave
+// nothing to repair, in which case no random numbers are consumed.
+func applyMask(maze *Maze, rng *rand.Rand) {
+	cellCols := (maze.Width - 1) / 2
+	cellCount := cellCols * ((maze.Height - 1) / 2)
+	index := func(row, col int) int { return (row-1)/2*cellCols + (col-1)/2 }
+
+	// Close every disabled cell together with its passages
+	for row := 1; row < maze.Height-1; row += 2 {
+		for col := 1; col < maze.Width-1; col += 2 {
+			if maze.cellEnabled(row, col) {
+				continue
+			}
+			maze.Grid[row][col] = true
+			for _, dir := range cellDirections {
+				maze.setWall(Position{Row: row + dir.Row/2, Col: col + dir.Col/2}, true)
+			}
+		}
+	}
+
+	// Group the enabled cells by their open passages, and collect the walls
+	// between enabled neighbors that could join two groups
+	uf := NewUnionFind(cellCount)
+	var walls []gridEdge
+	for row := 1; row < maze.Height-1; row += 2 {
+		for col := 1; col < maze.Width-1; col += 2 {
+			if !maze.cellEnabled(row, col) {
+				continue
+			}
+			maze.Grid[row][col] = false
+			for _, dir := range cellDirections[1:3] { // right, down
+				to, wall, ok := maze.neighbor(row, col, dir.Row, dir.Col)
+				if !ok {
+					continue
+				}
+				if maze.Grid[wall.Row][wall.Col] {
+					walls = append(walls, gridEdge{fromRow: row, fromCol: col, toRow: to.Row, toCol: to.Col, wall: wall})
+				} else {
+					uf.Union(index(row, col), index(to.Row, to.Col))
+				}
+			}
+		}
+	}
+
+	// Count the groups; a single one needs no repair
+	groups := 0
+	for row := 1; row < maze.Height-1; row += 2 {
+		for col := 1; col < maze.Width-1; col += 2 {
+			if maze.cellEnabled(row, col) && uf.Find(index(row, col)) == index(row, col) {
+				groups++
+			}
+		}
+	}
+	if groups <= 1 {
+		return
+	}
+
+	// Knock out random walls between groups until one is left, like Kruskal's algorithm
+	rng.Shuffle(len(walls), func(i, j int) { walls[i], walls[j] = walls[j], walls[i] })
+	for _, wall := range walls {
+		if uf.Union(index(wall.fromRow, wall.fromCol), index(wall.toRow, wall.toCol)) {
+			maze.setWall(wall.wall, false)
+			if groups--; groups == 1 {
+				return
+			}
+		}
+	}
+}
+
+// gridEdge is a closed wall between two neighboring cells of a square grid
+type gridEdge struct {
+	fromRow, fromCol int
+	toRow, toCol     int
+	wall             Position // Wall between the cells, on the border for wrap-around edges
+}
@@ -0,0 +1,283 @@
+package maze
+
+import (
+	"bytes"
+	"encoding/json"
+	"image"
+	"image/color"
+	"image/png"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// heartMask is a small heart-shaped mask used across the mask tests
+const heartMask = `XX...X...XX
+X.........X
+...........
+X.........X
+XX.......XX
+XXX.....XXX
+XXXX...XXXX
+XXXXX.XXXXX
+`
+
+func TestParseMaskText(t *testing.T) {
+	mask, err := ParseMaskText(strings.NewReader("..X\r\n.\r\nxX..\r\n\r\n\r\n"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if mask.Rows != 3 || mask.Cols != 4 {
+		t.Fatalf("Expected a 3x4 mask, got %dx%d", mask.Rows, mask.Cols)
+	}
+	if mask.GridWidth() != 9 || mask.GridHeight() != 7 {
+		t.Errorf("Expected a 9x7 grid, got %dx%d", mask.GridWidth(), mask.GridHeight())
+	}
+
+	expected := []string{"..X.", "....", "XX.."}
+	for row, line := range expected {
+		for col, char := range line {
+			if mask.Enabled(row, col) != (char == '.') {
+				t.Errorf("Cell (%d,%d): expected enabled=%v", row, col, char == '.')
+			}
+		}
+	}
+	if mask.Enabled(-1, 0) || mask.Enabled(3, 0) || mask.Enabled(0, 4) {
+		t.Error("Cells outside the mask should not be enabled")
+	}
+}
+
+func TestParseMaskTextErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		errMsg string
+	}{
+		{name: "empty", text: "", errMsg: "at least 2 cells"},
+		{name: "single cell", text: "X.X\nXXX\n", errMsg: "at least 2 cells"},
+		{name: "two regions", text: "..X..\n", errMsg: "single connected region"},
+		{name: "diagonal only", text: ".X\nX.\n", errMsg: "single connected region"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseMaskText(strings.NewReader(tt.text))
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("Expected error containing %q, got %v", tt.errMsg, err)
+			}
+		})
+	}
+}
+
+func TestParseMaskPNG(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 3, 2))
+	img.Set(0, 0, color.White)
+	img.Set(1, 0, color.Black)
+	img.Set(2, 0, color.NRGBA{R: 255, G: 255, B: 255, A: 0}) // Transparent
+	img.Set(0, 1, color.White)
+	img.Set(1, 1, color.NRGBA{R: 200, G: 200, B: 200, A: 255}) // Light gray
+	img.Set(2, 1, color.White)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+
+	mask, err := ParseMaskPNG(&buf)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if mask.Rows != 2 || mask.Cols != 3 {
+		t.Fatalf("Expected a 2x3 mask, got %dx%d", mask.Rows, mask.Cols)
+	}
+
+	expected := [][]bool{{true, false, false}, {true, true, true}}
+	for row := range expected {
+		for col := range expected[row] {
+			if mask.Enabled(row, col) != expected[row][col] {
+				t.Errorf("Cell (%d,%d): expected enabled=%v", row, col, expected[row][col])
+			}
+		}
+	}
+
+	if _, err := ParseMaskPNG(strings.NewReader("not a png")); err == nil {
+		t.Error("Expected error for invalid PNG data")
+	}
+}
+
+func TestLoadMask(t *testing.T) {
+	dir := t.TempDir()
+
+	textPath := filepath.Join(dir, "heart.txt")
+	if err := os.WriteFile(textPath, []byte(heartMask), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	mask, err := LoadMask(textPath)
+	if err != nil {
+		t.Fatalf("Unexpected error loading text mask: %v", err)
+	}
+	if mask.Rows != 8 || mask.Cols != 11 {
+		t.Errorf("Expected an 8x11 text mask, got %dx%d", mask.Rows, mask.Cols)
+	}
+
+	// PNG files are recognized by their content, not their name
+	img := image.NewGray(image.Rect(0, 0, 4, 1))
+	for x := 0; x < 4; x++ {
+		img.Set(x, 0, color.White)
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	pngPath := filepath.Join(dir, "strip.mask")
+	if err := os.WriteFile(pngPath, buf.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	mask, err = LoadMask(pngPath)
+	if err != nil {
+		t.Fatalf("Unexpected error loading PNG mask: %v", err)
+	}
+	if mask.Rows != 1 || mask.Cols != 4 {
+		t.Errorf("Expected a 1x4 PNG mask, got %dx%d", mask.Rows, mask.Cols)
+	}
+
+	if _, err := LoadMask(filepath.Join(dir, "missing.txt")); err == nil {
+		t.Error("Expected error for a missing file")
+	}
+}
+
+func TestMaskedGeneration(t *testing.T) {
+	mask, err := ParseMaskText(strings.NewReader(heartMask))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range GetSupportedAlgorithms() {
+		t.Run(name, func(t *testing.T) {
+			for _, seed := range []string{"1", "2", "3"} {
+				generator, err := NewGeneratorWithSeedAndAlgorithm(seed, name)
+				if err != nil {
+					t.Fatal(err)
+				}
+				maze, err := generator.GenerateWithOptions(mask.GridWidth(), mask.GridHeight(), GenerateOptions{Mask: mask})
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+
+				checkPerfectMaze(t, maze)
+				if maze.StartRow != 1 || maze.StartCol != 5 || maze.GoalRow != 15 || maze.GoalCol != 11 {
+					t.Errorf("Expected start (1,5) and goal (15,11), got (%d,%d) and (%d,%d)",
+						maze.StartRow, maze.StartCol, maze.GoalRow, maze.GoalCol)
+				}
+				if FindPath(maze) == nil {
+					t.Error("Expected a path from start to goal")
+				}
+			}
+		})
+	}
+}
+
+func TestMaskedAlgorithmsNeedNoRepair(t *testing.T) {
+	mask, err := ParseMaskText(strings.NewReader(heartMask))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Graph-walking algorithms follow the mask on their own
+	for _, name := range []string{"dfs", "kruskal", "wilson", "prim", "growing-tree", "aldous-broder", "hunt-and-kill"} {
+		algorithm, _ := NewAlgorithm(name)
+		for seed := int64(1); seed <= 10; seed++ {
+			maze := createTestMaze(mask.GridWidth(), mask.GridHeight())
+			maze.Mask = mask
+			algorithm.Generate(maze, 1, 5, rand.New(rand.NewSource(seed)))
+			checkPerfectMaze(t, maze)
+		}
+	}
+}
+
+func TestGenerateWithOptionsMaskErrors(t *testing.T) {
+	mask, _ := ParseMaskText(strings.NewReader(heartMask))
+	generator := NewGeneratorWithSeed("1")
+
+	if _, err := generator.GenerateWithOptions(21, 21, GenerateOptions{Mask: mask}); err == nil {
+		t.Error("Expected error for a maze size that does not match the mask")
+	}
+
+	invalid := NewMask(1, 3)
+	invalid.Disable(0, 1)
+	if _, err := generator.GenerateWithOptions(invalid.GridWidth(), invalid.GridHeight(), GenerateOptions{Mask: invalid}); err == nil {
+		t.Error("Expected error for a mask with two separate regions")
+	}
+
+	// The top-left cell is masked out, so there is no way in from the left there
+	_, err := generator.GenerateWithOptions(mask.GridWidth(), mask.GridHeight(), GenerateOptions{
+		Mask:     mask,
+		Start:    Placement{Mode: PlacementFixed, Row: 3, Col: 3},
+		Entrance: SideTop,
+	})
+	if err == nil || !strings.Contains(err.Error(), "masked-out cell") {
+		t.Errorf("Expected an error for an entrance into a masked-out cell, got %v", err)
+	}
+
+	// Fixed positions outside the mask are rejected
+	_, err = generator.GenerateWithOptions(mask.GridWidth(), mask.GridHeight(), GenerateOptions{
+		Mask:  mask,
+		Start: Placement{Mode: PlacementFixed, Row: 1, Col: 1},
+	})
+	if err == nil || !strings.Contains(err.Error(), "start 1,1 is outside the mask") {
+		t.Errorf("Expected an error for a start outside the mask, got %v", err)
+	}
+}
+
+func TestMaskedPlacementAndRendering(t *testing.T) {
+	mask := NewMask(3, 3)
+	mask.Disable(1, 1) // A ring of cells around a hole
+	generator, _ := NewGeneratorWithSeedAndAlgorithm("42", "dfs")
+	maze, err := generator.GenerateWithOptions(7, 7, GenerateOptions{
+		Mask:  mask,
+		Start: Placement{Mode: PlacementCenter},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if maze.StartRow != 1 || maze.StartCol != 3 {
+		t.Errorf("Expected the center start to move to the closest open cell (1,3), got (%d,%d)", maze.StartRow, maze.StartCol)
+	}
+
+	// Only the hole is outside the mask; its walls are drawn, the hole is blank
+	output := (&ASCIIRenderer{}).Render(maze)
+	lines := strings.Split(output, "\n")
+	if lines[3][3] != ' ' {
+		t.Errorf("Expected the masked-out cell to be blank, got %q", lines[3])
+	}
+
+	mask, _ = ParseMaskText(strings.NewReader(heartMask))
+	maze, err = generator.GenerateWithOptions(mask.GridWidth(), mask.GridHeight(), GenerateOptions{Mask: mask})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, renderer := range []Renderer{&ASCIIRenderer{}, &UnicodeRenderer{}} {
+		lines := strings.Split(renderer.Render(maze), "\n")
+		if strings.TrimSpace(string([]rune(lines[0])[:3])) != "" {
+			t.Errorf("%T: expected the corner outside the mask to be blank, got %q", renderer, lines[0])
+		}
+		if runes := []rune(lines[16]); runes[0] != ' ' || runes[11] == ' ' {
+			t.Errorf("%T: expected only the tip of the heart on the last line, got %q", renderer, lines[16])
+		}
+	}
+
+	var parsed JSON
+	if err := json.Unmarshal([]byte((&JSONRenderer{}).Render(maze)), &parsed); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	disabled := strings.Count(heartMask, "X")
+	if len(parsed.DisabledCells) != disabled {
+		t.Errorf("Expected %d disabled cells in JSON, got %d", disabled, len(parsed.DisabledCells))
+	}
+	if parsed.DisabledCells[0] != (Position{Row: 1, Col: 1}) {
+		t.Errorf("Expected the first disabled cell at (1,1), got %v", parsed.DisabledCells[0])
+	}
+}
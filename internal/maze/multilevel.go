@@ -0,0 +1,244 @@
+package maze
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MultiLevelMaze is a maze of several square floors stacked on top of each
+// other, joined by stairs. Each floor has the grid of a Maze of the same
+// width and height, and stairs lead from a cell straight up to the cell
+// above it. Floors are numbered from 0 at the bottom; the start is on the
+// bottom floor and the goal on the top floor.
+type MultiLevelMaze struct {
+	layoutMaze[Location]
+	Width  int // Grid width of each floor, as in Maze
+	Height int // Grid height of each floor, as in Maze
+	Depth  int // Number of floors
+}
+
+// Location is a position in grid coordinates on one floor of a multi-level maze
+type Location struct {
+	Floor int
+	Row   int
+	Col   int
+}
+
+// Multi-level neighbor slots: the four cells around a cell on its floor, and
+// the cells above and below it. A passage through LevelUp or LevelDown is a
+// flight of stairs.
+const (
+	LevelNorth = iota
+	LevelEast
+	LevelSouth
+	LevelWest
+	LevelUp
+	LevelDown
+	levelSlots
+)
+
+// levelOffsets lists the neighbor in each slot as (dFloor, dRow, dCol) in cells
+var levelOffsets = [levelSlots]Location{
+	{0, -1, 0}, {0, 0, 1}, {0, 1, 0}, {0, 0, -1}, {1, 0, 0}, {-1, 0, 0},
+}
+
+// NewMultiLevelMaze creates a multi-level maze with every wall and floor
+// standing. The start is in the top-left cell of the bottom floor and the
+// goal in the bottom-right cell of the top floor.
+func NewMultiLevelMaze(width, height, depth int) *MultiLevelMaze {
+	layout := levelLayout{floors: depth, rows: (height - 1) / 2, cols: (width - 1) / 2}
+	start, goal := Location{Floor: 0, Row: 1, Col: 1}, Location{Floor: depth - 1, Row: height - 2, Col: width - 2}
+	return &MultiLevelMaze{
+		layoutMaze: newLayoutMaze[Location](layout, start, goal),
+		Width:      width,
+		Height:     height,
+		Depth:      depth,
+	}
+}
+
+// GenerateMultiLevel creates a maze of depth floors, each of the given grid
+// size. The configured algorithm must be a GraphAlgorithm; it carves stairs
+// between floors just like passages within them.
+func (g *Generator) GenerateMultiLevel(width, height, depth int) (*MultiLevelMaze, error) {
+	if depth < 2 {
+		return nil, fmt.Errorf("multi-level maze must have at least 2 floors, got %d", depth)
+	}
+	if width < 3 || height < 3 || width%2 == 0 || height%2 == 0 {
+		return nil, fmt.Errorf("floors must have an odd grid size of at least 3x3, got %dx%d", width, height)
+	}
+
+	maze := NewMultiLevelMaze(width, height, depth)
+	if err := maze.generate(g, "multi-level mazes", maze.Start); err != nil {
+		return nil, err
+	}
+	return maze, nil
+}
+
+// FloorGrid returns the grid of one floor, with true for walls and false
+// for paths, like Maze.Grid
+func (m *MultiLevelMaze) FloorGrid(floor int) [][]bool {
+	grid := make([][]bool, m.Height)
+	for i := range grid {
+		grid[i] = make([]bool, m.Width)
+		for j := range grid[i] {
+			grid[i][j] = true
+		}
+	}
+
+	for row := 1; row < m.Height-1; row += 2 {
+		for col := 1; col < m.Width-1; col += 2 {
+			loc := Location{Floor: floor, Row: row, Col: col}
+			grid[row][col] = false
+			if m.Linked(loc, LevelEast) {
+				grid[row][col+1] = false
+			}
+			if m.Linked(loc, LevelSouth) {
+				grid[row+1][col] = false
+			}
+		}
+	}
+	return grid
+}
+
+// Solve sets SolutionPath to the shortest path from the start to the goal,
+// or to nil if there is none. Like FindPath, it lists every grid position
+// along the way, including the walls opened between cells; a step up or down
+// the stairs stays at the same row and column.
+func (m *MultiLevelMaze) Solve() {
+	m.layoutMaze.Solve()
+	if m.SolutionPath == nil {
+		return
+	}
+
+	path := []Location{m.SolutionPath[0]}
+	for _, next := range m.SolutionPath[1:] {
+		previous := path[len(path)-1]
+		if previous.Floor == next.Floor {
+			path = append(path, Location{Floor: next.Floor, Row: (previous.Row + next.Row) / 2, Col: (previous.Col + next.Col) / 2})
+		}
+		path = append(path, next)
+	}
+	m.SolutionPath = path
+}
+
+// levelLayout is the cellLayout of a multi-level maze, numbered floor by
+// floor in row-major order
+type levelLayout struct {
+	floors, rows, cols int
+}
+
+// CellCount implements the cellLayout interface
+func (l levelLayout) CellCount() int {
+	return l.floors * l.rows * l.cols
+}
+
+// Degree implements the cellLayout interface: every cell has six slots
+func (l levelLayout) Degree(int) int {
+	return levelSlots
+}
+
+// Neighbor implements the cellLayout interface
+func (l levelLayout) Neighbor(cell, slot int) int {
+	floor, row, col := cell/(l.rows*l.cols), cell/l.cols%l.rows, cell%l.cols
+	offset := levelOffsets[slot]
+	floor, row, col = floor+offset.Floor, row+offset.Row, col+offset.Col
+	if floor < 0 || floor >= l.floors || row < 0 || row >= l.rows || col < 0 || col >= l.cols {
+		return -1
+	}
+	return (floor*l.rows+row)*l.cols + col
+}
+
+// reverse implements the cellLayout interface: neighbors face each other
+func (l levelLayout) reverse(_, slot int) int {
+	switch slot {
+	case LevelUp:
+		return LevelDown
+	case LevelDown:
+		return LevelUp
+	default:
+		return (slot + 2) % 4
+	}
+}
+
+// cell numbers the cell at a location in grid coordinates
+func (l levelLayout) cell(loc Location) int {
+	return (loc.Floor*l.rows+(loc.Row-1)/2)*l.cols + (loc.Col-1)/2
+}
+
+// position converts a cell number back to grid coordinates
+func (l levelLayout) position(cell int) Location {
+	return Location{Floor: cell / (l.rows * l.cols), Row: 2*(cell/l.cols%l.rows) + 1, Col: 2*(cell%l.cols) + 1}
+}
+
+// levelGlyphs are the markers that a text renderer puts on the floors of a
+// multi-level maze
+type levelGlyphs struct {
+	start, goal    rune
+	up, down, both rune // Stairs up, down, and both up and down
+}
+
+// renderMultiLevelText draws each floor of a multi-level maze with a text
+// renderer, headed "Floor 1" for the bottom floor and so on, and marks the
+// start, the goal and the stairs on them. The floors are printed from the
+// bottom up, either one after another or side by side.
+func renderMultiLevelText(m *MultiLevelMaze, renderer Renderer, glyphs levelGlyphs, sideBySide bool) string {
+	floors := make([][]string, m.Depth)
+	for floor := range floors {
+		// The start and goal are marked below, since they are only on some floors
+		plan := &Maze{Width: m.Width, Height: m.Height, Grid: m.FloorGrid(floor), StartRow: -1, StartCol: -1, GoalRow: -1, GoalCol: -1}
+		for _, loc := range m.SolutionPath {
+			if loc.Floor == floor {
+				plan.SolutionPath = append(plan.SolutionPath, Position{Row: loc.Row, Col: loc.Col})
+			}
+		}
+
+		lines := strings.Split(strings.TrimSuffix(renderer.Render(plan), "\n"), "\n")
+		for row := 1; row < m.Height-1; row += 2 {
+			line := []rune(lines[row])
+			for col := 1; col < m.Width-1; col += 2 {
+				loc := Location{Floor: floor, Row: row, Col: col}
+				up, down := m.Linked(loc, LevelUp), m.Linked(loc, LevelDown)
+				switch {
+				case loc == m.Start:
+					line[col] = glyphs.start
+				case loc == m.Goal:
+					line[col] = glyphs.goal
+				case up && down:
+					line[col] = glyphs.both
+				case up:
+					line[col] = glyphs.up
+				case down:
+					line[col] = glyphs.down
+				}
+			}
+			lines[row] = string(line)
+		}
+		floors[floor] = append([]string{fmt.Sprintf("Floor %d", floor+1)}, lines...)
+	}
+
+	var sb strings.Builder
+	if !sideBySide {
+		for floor, lines := range floors {
+			if floor > 0 {
+				sb.WriteRune('\n')
+			}
+			for _, line := range lines {
+				sb.WriteString(line)
+				sb.WriteRune('\n')
+			}
+		}
+		return sb.String()
+	}
+
+	// Every floor gets a column as wide as its grid or its header, whichever is wider
+	width := max(m.Width, len(floors[m.Depth-1][0]))
+	for i := range floors[0] {
+		row := make([]string, len(floors))
+		for floor, lines := range floors {
+			row[floor] = lines[i] + strings.Repeat(" ", width-len([]rune(lines[i])))
+		}
+		sb.WriteString(strings.TrimRight(strings.Join(row, "  "), " "))
+		sb.WriteRune('\n')
+	}
+	return sb.String()
+}
@@ -0,0 +1,124 @@
+package maze
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestGenerateMultiLevelErrors(t *testing.T) {
+	generator := NewGeneratorWithSeed("1")
+	tests := []struct {
+		width, height, depth int
+		errMsg               string
+	}{
+		{11, 11, 1, "at least 2 floors"},
+		{10, 11, 2, "odd grid size"},
+		{11, 1, 2, "odd grid size"},
+	}
+	for _, tt := range tests {
+		_, err := generator.GenerateMultiLevel(tt.width, tt.height, tt.depth)
+		if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+			t.Errorf("%dx%dx%d: expected error containing %q, got %v", tt.width, tt.height, tt.depth, tt.errMsg, err)
+		}
+	}
+}
+
+func TestMultiLevelNeighbors(t *testing.T) {
+	layout := levelLayout{floors: 2, rows: 3, cols: 4}
+	middle := layout.cell(Location{Floor: 0, Row: 3, Col: 3})
+	if loc := layout.position(middle); loc != (Location{Floor: 0, Row: 3, Col: 3}) {
+		t.Errorf("Expected the cell to convert back to its location, got %v", loc)
+	}
+
+	expected := [levelSlots]Location{{0, 1, 3}, {0, 3, 5}, {0, 5, 3}, {0, 3, 1}, {1, 3, 3}, {-1, 0, 0}}
+	for slot, loc := range expected {
+		next := layout.Neighbor(middle, slot)
+		if loc.Floor < 0 {
+			if next != -1 {
+				t.Errorf("Expected no neighbor below the bottom floor, got %v", layout.position(next))
+			}
+			continue
+		}
+		if next < 0 || layout.position(next) != loc {
+			t.Errorf("Neighbor in slot %d: expected %v, got cell %d", slot, loc, next)
+			continue
+		}
+		if layout.Neighbor(next, layout.reverse(next, slot)) != middle {
+			t.Errorf("Neighbor in slot %d does not lead back", slot)
+		}
+	}
+}
+
+// createStairsMaze returns a maze of two floors of 2x1 cells. The start
+// corridor leads to stairs up in the right cell, and the goal is on the
+// left cell of the top floor:
+//
+//	Floor 1  Floor 2
+//	#####    #####
+//	#● <#    #○ >#
+//	#####    #####
+func createStairsMaze() *MultiLevelMaze {
+	maze := NewMultiLevelMaze(5, 3, 2)
+	maze.link(Location{Floor: 0, Row: 1, Col: 1}, LevelEast)
+	maze.link(Location{Floor: 0, Row: 1, Col: 3}, LevelUp)
+	maze.link(Location{Floor: 1, Row: 1, Col: 3}, LevelWest)
+	maze.Goal = Location{Floor: 1, Row: 1, Col: 1}
+	return maze
+}
+
+func TestSolveMultiLevel(t *testing.T) {
+	maze := createStairsMaze()
+	maze.Solve()
+	path := maze.SolutionPath
+	expected := []Location{{0, 1, 1}, {0, 1, 2}, {0, 1, 3}, {1, 1, 3}, {1, 1, 2}, {1, 1, 1}}
+	if len(path) != len(expected) {
+		t.Fatalf("Expected path %v, got %v", expected, path)
+	}
+	for i := range expected {
+		if path[i] != expected[i] {
+			t.Fatalf("Expected path %v, got %v", expected, path)
+		}
+	}
+
+	grid := maze.FloorGrid(1)
+	if grid[1][1] || grid[1][2] || grid[1][3] || !grid[0][2] || !grid[1][4] {
+		t.Errorf("Expected an open corridor across the top floor, got %v", grid)
+	}
+}
+
+func TestRenderMultiLevel(t *testing.T) {
+	maze := createStairsMaze()
+
+	ascii := (&ASCIIRenderer{}).RenderMultiLevel(maze, false)
+	expected := "Floor 1\n#####\n#● <#\n#####\n\nFloor 2\n#####\n#○ >#\n#####\n"
+	if ascii != expected {
+		t.Errorf("Expected floors one after another:\n%s\ngot:\n%s", expected, ascii)
+	}
+
+	ascii = (&ASCIIRenderer{}).RenderMultiLevel(maze, true)
+	expected = "Floor 1  Floor 2\n#####    #####\n#● <#    #○ >#\n#####    #####\n"
+	if ascii != expected {
+		t.Errorf("Expected floors side by side:\n%s\ngot:\n%s", expected, ascii)
+	}
+
+	maze.Solve()
+	unicode := (&UnicodeRenderer{}).RenderMultiLevel(maze, true)
+	if !strings.Contains(unicode, "▲") || !strings.Contains(unicode, "▼") || !strings.Contains(unicode, "•") {
+		t.Errorf("Expected stair markers and the solution in the Unicode output, got:\n%s", unicode)
+	}
+
+	var parsed MultiLevelJSON
+	if err := json.Unmarshal([]byte((&JSONRenderer{}).RenderMultiLevel(maze, false)), &parsed); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	stairs := Location{Floor: 0, Row: 1, Col: 3}
+	if parsed.Depth != 2 || len(parsed.Floors) != 2 || len(parsed.Stairs) != 1 || parsed.Stairs[0] != stairs || len(parsed.SolutionPath) != 6 {
+		t.Errorf("Unexpected JSON output: %+v", parsed)
+	}
+
+	renderer, _ := NewRenderer("svg")
+	if _, ok := renderer.(MultiLevelRenderer); ok {
+		t.Error("Expected SVG output not to support multi-level mazes")
+	}
+}
@@ -0,0 +1,136 @@
+package maze
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// Side selects a side of the maze border for an entrance or exit opening
+type Side int
+
+const (
+	// SideNone leaves the border closed
+	SideNone Side = iota
+	// SideTop opens the top border
+	SideTop
+	// SideRight opens the right border
+	SideRight
+	// SideBottom opens the bottom border
+	SideBottom
+	// SideLeft opens the left border
+	SideLeft
+	// SideRandom opens a randomly chosen side
+	SideRandom
+)
+
+// sideNames maps the names accepted by ParseSide to their sides
+var sideNames = map[string]Side{
+	"top":    SideTop,
+	"right":  SideRight,
+	"bottom": SideBottom,
+	"left":   SideLeft,
+	"random": SideRandom,
+}
+
+// ParseSide parses a border side: "top", "right", "bottom", "left" or "random".
+// An empty string selects SideNone.
+func ParseSide(s string) (Side, error) {
+	value := strings.ToLower(strings.TrimSpace(s))
+	if value == "" {
+		return SideNone, nil
+	}
+	if side, ok := sideNames[value]; ok {
+		return side, nil
+	}
+	return SideNone, fmt.Errorf("invalid side: %q (supported: top, right, bottom, left, random)", s)
+}
+
+// String returns the side in the form accepted by ParseSide
+func (s Side) String() string {
+	for name, side := range sideNames {
+		if side == s {
+			return name
+		}
+	}
+	return ""
+}
+
+// placeOpenings carves the entrance and exit openings into the border. Each
+// opening is the border position next to the edge cell in line with the start
+// (for the entrance) or goal (for the exit), and then becomes the new start or
+// goal so that the solution runs from opening to opening.
+func placeOpenings(maze *Maze, entrance, exit Side, rng *rand.Rand) error {
+	start := Position{Row: maze.StartRow, Col: maze.StartCol}
+	goal := Position{Row: maze.GoalRow, Col: maze.GoalCol}
+
+	var err error
+	if entrance != SideNone {
+		if start, err = borderOpening(maze, "entrance", resolveSide(maze, entrance, rng), start); err != nil {
+			return err
+		}
+	}
+	if exit != SideNone {
+		if goal, err = borderOpening(maze, "exit", resolveSide(maze, exit, rng), goal); err != nil {
+			return err
+		}
+	}
+	if start == goal {
+		return fmt.Errorf("entrance and exit openings are both at %d,%d", start.Row, start.Col)
+	}
+
+	if entrance != SideNone {
+		maze.Grid[start.Row][start.Col] = false
+		maze.Openings = append(maze.Openings, start)
+	}
+	if exit != SideNone {
+		maze.Grid[goal.Row][goal.Col] = false
+		maze.Openings = append(maze.Openings, goal)
+	}
+
+	maze.StartRow, maze.StartCol = start.Row, start.Col
+	maze.GoalRow, maze.GoalCol = goal.Row, goal.Col
+	return nil
+}
+
+// resolveSide replaces SideRandom with one of the sides that do not wrap
+// around, or with SideNone if every side wraps
+func resolveSide(maze *Maze, side Side, rng *rand.Rand) Side {
+	if side != SideRandom {
+		return side
+	}
+	switch maze.Topology {
+	case TopologyCylinder:
+		return []Side{SideTop, SideBottom}[rng.Intn(2)]
+	case TopologyTorus:
+		return SideNone
+	default:
+		return SideTop + Side(rng.Intn(4))
+	}
+}
+
+// borderOpening returns the border position on side in line with cell. The
+// cell just inside the opening must be open, which only matters for masks.
+func borderOpening(maze *Maze, name string, side Side, cell Position) (Position, error) {
+	var opening, inside Position
+	switch side {
+	case SideNone:
+		return Position{}, fmt.Errorf("%s needs a border side, but every side of a %s maze wraps around", name, maze.Topology)
+	case SideTop:
+		opening, inside = Position{Row: 0, Col: cell.Col}, Position{Row: 1, Col: cell.Col}
+	case SideBottom:
+		opening, inside = Position{Row: maze.Height - 1, Col: cell.Col}, Position{Row: maze.Height - 2, Col: cell.Col}
+	case SideLeft:
+		opening, inside = Position{Row: cell.Row, Col: 0}, Position{Row: cell.Row, Col: 1}
+	default:
+		opening, inside = Position{Row: cell.Row, Col: maze.Width - 1}, Position{Row: cell.Row, Col: maze.Width - 2}
+	}
+
+	if _, wraps := maze.mirror(opening); wraps {
+		return Position{}, fmt.Errorf("%s cannot be on the %s side of a %s maze, which wraps around", name, side, maze.Topology)
+	}
+	if !isPathCell(maze, inside) {
+		return Position{}, fmt.Errorf("%s at %d,%d would lead into a masked-out cell", name, opening.Row, opening.Col)
+	}
+	return opening, nil
+}
@@ -6,56 +6,146 @@ type Position struct {
 	Col int
 }
 
-// FindPath finds the shortest path from start to goal using BFS
+// FindPath finds the shortest path from start to goal using BFS. A path
+// that passes a crossing of a weave maze lists the crossing cell once for
+// each corridor it takes through it.
 func FindPath(maze *Maze) []Position {
 	if maze.Grid[maze.StartRow][maze.StartCol] || maze.Grid[maze.GoalRow][maze.GoalCol] {
 		return nil // Start or goal is blocked
 	}
 
-	// BFS to find shortest path
-	queue := []Position{{Row: maze.StartRow, Col: maze.StartCol}}
-	visited := make(map[Position]bool)
-	parent := make(map[Position]Position)
+	start := Position{Row: maze.StartRow, Col: maze.StartCol}
+	goal := Position{Row: maze.GoalRow, Col: maze.GoalCol}
+	if start == goal {
+		return []Position{start}
+	}
+
+	parent := make(map[pathState]pathState)
+	var end *pathState
+	maze.walk(start, func(next, from pathState, _ int) bool {
+		parent[next] = from
+		if next.Position == goal {
+			end = &next
+		}
+		return end != nil
+	})
+	if end == nil {
+		return nil // No path found
+	}
+
+	// Reconstruct path
+	path := []Position{}
+	for state := *end; state != (pathState{Position: start}); state = parent[state] {
+		path = append(path, state.Position)
+	}
+	path = append(path, start)
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// Distances returns the number of steps along open grid positions from origin
+// to every position of the maze, using BFS. Walls and positions that cannot be
+// reached from origin have distance -1. A crossing of a weave maze has the
+// distance of whichever of its corridors is reached first.
+func Distances(maze *Maze, origin Position) [][]int {
+	distances := make([][]int, maze.Height)
+	for i := range distances {
+		distances[i] = make([]int, maze.Width)
+		for j := range distances[i] {
+			distances[i][j] = -1
+		}
+	}
+	if maze.Grid[origin.Row][origin.Col] {
+		return distances
+	}
+
+	distances[origin.Row][origin.Col] = 0
+	maze.walk(origin, func(next, _ pathState, steps int) bool {
+		if distances[next.Row][next.Col] < 0 {
+			distances[next.Row][next.Col] = steps
+		}
+		return false
+	})
+	return distances
+}
 
-	visited[Position{Row: maze.StartRow, Col: maze.StartCol}] = true
+// pathState is a place that a path can reach. Everywhere except at the
+// crossings of a weave maze it is just a position; at a crossing, the two
+// corridors are separate places.
+type pathState struct {
+	Position
+	vertical bool // At a crossing, on the corridor running up and down
+}
+
+// walk visits the open positions that can be reached from the open position
+// origin in BFS order, calling reached once for each state with the state it
+// was reached from and its number of steps from origin. It stops as soon as
+// reached returns true. Corridors only go straight through crossings.
+func (m *Maze) walk(origin Position, reached func(next, from pathState, steps int) bool) {
+	const horizontal, vertical = 1, 2
+	visited := make([][]uint8, m.Height) // Bit set of the states of each position that were reached
+	for i := range visited {
+		visited[i] = make([]uint8, m.Width)
+	}
+	visited[origin.Row][origin.Col] = horizontal
+	queue := []pathState{{Position: origin}}
+	steps := []int{0} // Steps from origin of each state in the queue
 
 	// Directions: up, right, down, left
 	directions := []Position{{-1, 0}, {0, 1}, {1, 0}, {0, -1}}
 
 	for len(queue) > 0 {
-		current := queue[0]
-		queue = queue[1:]
-
-		// Check if we reached the goal
-		if current.Row == maze.GoalRow && current.Col == maze.GoalCol {
-			// Reconstruct path
-			path := []Position{}
-			for pos := current; pos != (Position{Row: maze.StartRow, Col: maze.StartCol}); pos = parent[pos] {
-				path = append([]Position{pos}, path...)
-			}
-			path = append([]Position{{Row: maze.StartRow, Col: maze.StartCol}}, path...)
-			return path
-		}
+		current, distance := queue[0], steps[0]
+		queue, steps = queue[1:], steps[1:]
+		_, crossing := m.Crossings[current.Position]
 
-		// Explore neighbors
 		for _, dir := range directions {
-			newPos := Position{
-				Row: current.Row + dir.Row,
-				Col: current.Col + dir.Col,
+			if crossing && (dir.Row != 0) != current.vertical {
+				continue // No turning at a crossing
+			}
+			newPos, inside := m.gridStep(current.Position, dir)
+			if !inside || m.Grid[newPos.Row][newPos.Col] {
+				continue
 			}
 
-			// Check bounds and if it's a valid path
-			if newPos.Row >= 0 && newPos.Row < maze.Height &&
-				newPos.Col >= 0 && newPos.Col < maze.Width &&
-				!maze.Grid[newPos.Row][newPos.Col] && // Not a wall
-				!visited[newPos] {
-
-				visited[newPos] = true
-				parent[newPos] = current
-				queue = append(queue, newPos)
+			next, bit := pathState{Position: newPos}, uint8(horizontal)
+			if _, ok := m.Crossings[newPos]; ok && dir.Row != 0 {
+				next.vertical, bit = true, vertical
+			}
+			if visited[newPos.Row][newPos.Col]&bit != 0 {
+				continue
 			}
+			visited[newPos.Row][newPos.Col] |= bit
+			if reached(next, current, distance+1) {
+				return
+			}
+			queue, steps = append(queue, next), append(steps, distance+1)
 		}
 	}
+}
+
+// FindDiameter returns the two ends of the maze's longest path, found with two
+// BFS passes: the cell farthest from the start, then the cell farthest from that
+// one. The result is exact for perfect mazes, which are trees; for mazes with
+// loops it is a close approximation. The end that comes first in row-major
+// order is returned first.
+func FindDiameter(maze *Maze) (Position, Position) {
+	cells := pathCells(maze)
+	if len(cells) == 0 {
+		return Position{}, Position{}
+	}
 
-	return nil // No path found
+	origin := Position{Row: maze.StartRow, Col: maze.StartCol}
+	if !isPathCell(maze, origin) {
+		origin = cells[0]
+	}
+
+	first := farthestCell(maze, origin)
+	second := farthestCell(maze, first)
+	if second.Row < first.Row || (second.Row == first.Row && second.Col < first.Col) {
+		first, second = second, first
+	}
+	return first, second
 }
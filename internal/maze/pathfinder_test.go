@@ -182,10 +182,74 @@ func TestFindPathConnectivity(t *testing.T) {
 	}
 }
 
-// Helper function for absolute value
-func abs(x int) int {
-	if x < 0 {
-		return -x
+// TestDistances tests BFS distances on a small maze with a wall in the middle
+func TestDistances(t *testing.T) {
+	maze := &Maze{
+		Width:  5,
+		Height: 5,
+		Grid: [][]bool{
+			{true, true, true, true, true},
+			{true, false, false, false, true},
+			{true, false, true, true, true},
+			{true, false, false, false, true},
+			{true, true, true, true, true},
+		},
+	}
+
+	distances := Distances(maze, Position{Row: 1, Col: 3})
+
+	expected := map[Position]int{
+		{Row: 1, Col: 3}: 0,
+		{Row: 1, Col: 1}: 2,
+		{Row: 3, Col: 1}: 4,
+		{Row: 3, Col: 3}: 6,
+		{Row: 2, Col: 2}: -1, // Wall
+		{Row: 0, Col: 0}: -1, // Border
+	}
+	for pos, want := range expected {
+		if got := distances[pos.Row][pos.Col]; got != want {
+			t.Errorf("Expected distance %d at (%d,%d), got %d", want, pos.Row, pos.Col, got)
+		}
+	}
+
+	// Distances along the solution path must match its length
+	generator := NewGeneratorWithSeed("test123")
+	generated := generator.Generate(21, 21)
+	path := FindPath(generated)
+	distances = Distances(generated, Position{Row: generated.StartRow, Col: generated.StartCol})
+	if got := distances[generated.GoalRow][generated.GoalCol]; got != len(path)-1 {
+		t.Errorf("Expected goal distance %d, got %d", len(path)-1, got)
+	}
+}
+
+// TestFindDiameter compares the two-pass result with a brute-force search over all cell pairs
+func TestFindDiameter(t *testing.T) {
+	for _, algorithm := range []string{"dfs", "kruskal", "prim", "binary-tree"} {
+		for _, seed := range []string{"1", "2", "3"} {
+			generator, err := NewGeneratorWithSeedAndAlgorithm(seed, algorithm)
+			if err != nil {
+				t.Fatal(err)
+			}
+			maze := generator.Generate(15, 11)
+
+			longest := 0
+			for _, cell := range pathCells(maze) {
+				distances := Distances(maze, cell)
+				for _, other := range pathCells(maze) {
+					if distances[other.Row][other.Col] > longest {
+						longest = distances[other.Row][other.Col]
+					}
+				}
+			}
+
+			first, second := FindDiameter(maze)
+			if got := Distances(maze, first)[second.Row][second.Col]; got != longest {
+				t.Errorf("%s seed %s: expected diameter %d, got %d between (%d,%d) and (%d,%d)",
+					algorithm, seed, longest, got, first.Row, first.Col, second.Row, second.Col)
+			}
+			if second.Row < first.Row || (second.Row == first.Row && second.Col < first.Col) {
+				t.Errorf("%s seed %s: expected ends in row-major order", algorithm, seed)
+			}
+		}
 	}
-	return x
 }
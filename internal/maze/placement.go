@@ -0,0 +1,258 @@
+package maze
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// PlacementMode selects how the start or goal position of a maze is chosen
+type PlacementMode int
+
+const (
+	// PlacementDefault keeps the usual position: the top-left cell for the
+	// start and the bottom-right cell for the goal
+	PlacementDefault PlacementMode = iota
+	// PlacementFixed uses the cell at the given row and column
+	PlacementFixed
+	// PlacementRandom picks a random cell, different from the other endpoint
+	PlacementRandom
+	// PlacementFarthest picks the cell farthest from the other endpoint along the maze paths
+	PlacementFarthest
+	// PlacementCenter picks the cell closest to the middle of the grid, other
+	// than the other endpoint
+	PlacementCenter
+)
+
+// placementKeywords maps the keywords accepted by ParsePlacement to their modes
+var placementKeywords = map[string]PlacementMode{
+	"random":   PlacementRandom,
+	"farthest": PlacementFarthest,
+	"center":   PlacementCenter,
+}
+
+// Placement describes where the start or goal of a maze goes.
+// Row and Col are grid coordinates and are only used by PlacementFixed.
+type Placement struct {
+	Mode     PlacementMode
+	Row, Col int
+}
+
+// ParsePlacement parses a position such as "3,5" (row,col in grid coordinates)
+// or one of the keywords "random", "farthest" and "center".
+// An empty string selects PlacementDefault.
+func ParsePlacement(s string) (Placement, error) {
+	value := strings.ToLower(strings.TrimSpace(s))
+	if value == "" {
+		return Placement{}, nil
+	}
+	if mode, ok := placementKeywords[value]; ok {
+		return Placement{Mode: mode}, nil
+	}
+
+	parts := strings.Split(value, ",")
+	if len(parts) == 2 {
+		row, rowErr := strconv.Atoi(strings.TrimSpace(parts[0]))
+		col, colErr := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if rowErr == nil && colErr == nil {
+			return Placement{Mode: PlacementFixed, Row: row, Col: col}, nil
+		}
+	}
+	return Placement{}, fmt.Errorf("invalid position: %q (expected row,col or one of: random, farthest, center)", s)
+}
+
+// String returns the placement in the form accepted by ParsePlacement
+func (p Placement) String() string {
+	switch p.Mode {
+	case PlacementFixed:
+		return fmt.Sprintf("%d,%d", p.Row, p.Col)
+	case PlacementRandom:
+		return "random"
+	case PlacementFarthest:
+		return "farthest"
+	case PlacementCenter:
+		return "center"
+	default:
+		return ""
+	}
+}
+
+// PlaceAtDiameter moves the start and goal to the two ends of the maze's longest
+// path (see FindDiameter), which gives the hardest start and goal pair for the
+// maze. Any previously computed solution path is cleared.
+func (m *Maze) PlaceAtDiameter() {
+	start, goal := FindDiameter(m)
+	m.StartRow, m.StartCol = start.Row, start.Col
+	m.GoalRow, m.GoalCol = goal.Row, goal.Col
+	m.SolutionPath = nil
+}
+
+// placeEndpoints moves the start and goal of a generated maze according to the
+// placements. An endpoint whose position depends on the other one is resolved
+// after it (see placementRank), so a random or center endpoint never lands on
+// the other one and a "farthest" one is measured from it. The two cannot both
+// be farthest.
+func placeEndpoints(maze *Maze, start, goal Placement, rng *rand.Rand) error {
+	if start.Mode == PlacementFarthest && goal.Mode == PlacementFarthest {
+		return fmt.Errorf("start and goal cannot both be placed farthest from each other; use the longest path placement instead")
+	}
+
+	startPos := Position{Row: maze.StartRow, Col: maze.StartCol}
+	goalPos := Position{Row: maze.GoalRow, Col: maze.GoalCol}
+
+	var err error
+	if placementRank(goal) < placementRank(start) {
+		if goalPos, err = resolvePlacement(maze, "goal", goal, goalPos, nil, rng); err != nil {
+			return err
+		}
+		if startPos, err = resolvePlacement(maze, "start", start, startPos, &goalPos, rng); err != nil {
+			return err
+		}
+	} else {
+		if startPos, err = resolvePlacement(maze, "start", start, startPos, nil, rng); err != nil {
+			return err
+		}
+		if goalPos, err = resolvePlacement(maze, "goal", goal, goalPos, &startPos, rng); err != nil {
+			return err
+		}
+	}
+
+	if startPos == goalPos {
+		return fmt.Errorf("start and goal must be different cells, both are %d,%d", startPos.Row, startPos.Col)
+	}
+
+	maze.StartRow, maze.StartCol = startPos.Row, startPos.Col
+	maze.GoalRow, maze.GoalCol = goalPos.Row, goalPos.Col
+	return nil
+}
+
+// placementRank orders the endpoints for placeEndpoints: fixed and default
+// positions come first, then center and random ones, which avoid the other
+// endpoint, and farthest ones, which are measured from it, come last
+func placementRank(placement Placement) int {
+	switch placement.Mode {
+	case PlacementCenter:
+		return 1
+	case PlacementRandom:
+		return 2
+	case PlacementFarthest:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// resolvePlacement returns the position chosen by placement. current is the
+// default position and other, if known, is the opposite endpoint.
+func resolvePlacement(maze *Maze, name string, placement Placement, current Position, other *Position, rng *rand.Rand) (Position, error) {
+	switch placement.Mode {
+	case PlacementFixed:
+		pos := Position{Row: placement.Row, Col: placement.Col}
+		if err := checkPathCell(maze, pos); err != nil {
+			return Position{}, fmt.Errorf("%s %d,%d %w", name, pos.Row, pos.Col, err)
+		}
+		return pos, nil
+	case PlacementRandom:
+		cells := make([]Position, 0)
+		for _, cell := range pathCells(maze) {
+			if other == nil || cell != *other {
+				cells = append(cells, cell)
+			}
+		}
+		if len(cells) == 0 {
+			return Position{}, fmt.Errorf("no free path cell for the %s", name)
+		}
+		return cells[rng.Intn(len(cells))], nil
+	case PlacementFarthest:
+		return farthestCell(maze, *other), nil
+	case PlacementCenter:
+		return centerCell(maze, other), nil
+	default:
+		return current, nil
+	}
+}
+
+// isPathCell reports whether pos is an open cell, i.e. an odd grid position
+// inside the border that is not a wall. Crossings of weave mazes do not
+// count, since a marker on them would not tell which corridor it is on.
+func isPathCell(maze *Maze, pos Position) bool {
+	return checkPathCell(maze, pos) == nil
+}
+
+// checkPathCell returns an error saying why pos is not an open cell, or nil
+// if it is one
+func checkPathCell(maze *Maze, pos Position) error {
+	_, crossing := maze.Crossings[pos]
+	switch {
+	case pos.Row < 1 || pos.Row > maze.Height-2 || pos.Col < 1 || pos.Col > maze.Width-2:
+		return fmt.Errorf("is outside the maze: row must be in 1..%d and column in 1..%d", maze.Height-2, maze.Width-2)
+	case pos.Row%2 == 0 || pos.Col%2 == 0:
+		return fmt.Errorf("is not a cell position: row and column must both be odd")
+	case !maze.cellEnabled(pos.Row, pos.Col):
+		return fmt.Errorf("is outside the mask")
+	case maze.Grid[pos.Row][pos.Col]:
+		return fmt.Errorf("is a wall")
+	case crossing:
+		return fmt.Errorf("is a weave crossing")
+	}
+	return nil
+}
+
+// pathCells returns every open cell of the maze in row-major order, except
+// for crossings
+func pathCells(maze *Maze) []Position {
+	cells := make([]Position, 0)
+	for row := 1; row < maze.Height-1; row += 2 {
+		for col := 1; col < maze.Width-1; col += 2 {
+			if isPathCell(maze, Position{Row: row, Col: col}) {
+				cells = append(cells, Position{Row: row, Col: col})
+			}
+		}
+	}
+	return cells
+}
+
+// farthestCell returns the open cell with the longest shortest path from
+// origin, preferring the first one in row-major order on ties
+func farthestCell(maze *Maze, origin Position) Position {
+	distances := Distances(maze, origin)
+	farthest := origin
+	for _, cell := range pathCells(maze) {
+		if distances[cell.Row][cell.Col] > distances[farthest.Row][farthest.Col] {
+			farthest = cell
+		}
+	}
+	return farthest
+}
+
+// centerCell returns the cell in the middle of the grid, or the open cell
+// closest to it when the middle is masked out or taken by other, if given
+func centerCell(maze *Maze, other *Position) Position {
+	cellRows := (maze.Height - 1) / 2
+	cellCols := (maze.Width - 1) / 2
+	center := Position{Row: 2*(cellRows/2) + 1, Col: 2*(cellCols/2) + 1}
+	if isPathCell(maze, center) && (other == nil || center != *other) {
+		return center
+	}
+
+	closest, closestDistance := center, -1
+	for _, cell := range pathCells(maze) {
+		if other != nil && cell == *other {
+			continue
+		}
+		distance := abs(cell.Row-center.Row) + abs(cell.Col-center.Col)
+		if closestDistance < 0 || distance < closestDistance {
+			closest, closestDistance = cell, distance
+		}
+	}
+	return closest
+}
+
+// abs returns the absolute value of x
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,164 @@
+package maze
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// PolarMaze is a circular maze of concentric rings. Ring 0 is a single cell
+// at the centre, and each ring further out is split into as many cells as
+// keeps them roughly square, so the outer rings have more cells. Cells are
+// addressed by Position with Row as the ring and Col as the index of the
+// cell, counted clockwise from the top. The start is on the outer rim, where
+// the entrance is, and the goal is the centre.
+type PolarMaze struct {
+	layoutMaze[Position]
+	Rings     int
+	RingSizes []int // Number of cells in each ring
+}
+
+// Polar neighbor slots. A cell's outward neighbors, one or more when the
+// ring outside it has more cells, follow PolarOutward in clockwise order.
+const (
+	PolarInward = iota
+	PolarClockwise
+	PolarCounterClockwise
+	PolarOutward
+)
+
+// NewPolarMaze creates a polar maze with every wall standing, the goal in the
+// centre and the start in the first cell of the outer ring
+func NewPolarMaze(rings int) *PolarMaze {
+	layout := newPolarLayout(rings)
+	start, goal := Position{Row: rings - 1, Col: 0}, Position{Row: 0, Col: 0}
+	return &PolarMaze{
+		layoutMaze: newLayoutMaze[Position](layout, start, goal),
+		Rings:      rings,
+		RingSizes:  layout.sizes,
+	}
+}
+
+// GeneratePolar creates a polar maze with the given number of rings. The
+// configured algorithm must be a GraphAlgorithm. The start is the cell of the
+// outer ring that is farthest from the centre, making the solution as long as
+// possible.
+func (g *Generator) GeneratePolar(rings int) (*PolarMaze, error) {
+	if rings < 2 {
+		return nil, fmt.Errorf("polar maze must have at least 2 rings, got %d", rings)
+	}
+
+	maze := NewPolarMaze(rings)
+	if err := maze.generate(g, "polar mazes", maze.Goal); err != nil {
+		return nil, err
+	}
+
+	distances := maze.graph.distances(maze.layout.cell(maze.Goal))
+	outer := rings - 1
+	for col := 1; col < maze.RingSizes[outer]; col++ {
+		if distances[maze.layout.cell(Position{Row: outer, Col: col})] > distances[maze.layout.cell(maze.Start)] {
+			maze.Start = Position{Row: outer, Col: col}
+		}
+	}
+	return maze, nil
+}
+
+// Render draws the maze with a renderer that supports polar mazes, and
+// reports false if the renderer does not
+func (p *PolarMaze) Render(renderer Renderer) (string, bool) {
+	polarRenderer, ok := renderer.(PolarRenderer)
+	if !ok {
+		return "", false
+	}
+	return polarRenderer.RenderPolar(p), true
+}
+
+// polarLayout is the cellLayout of a polar grid, numbered ring by ring from
+// the centre outwards
+type polarLayout struct {
+	sizes  []int // Number of cells in each ring
+	starts []int // Number of the first cell of each ring
+}
+
+// newPolarLayout splits the rings into cells. Every ring is one unit thick;
+// a ring gets twice (or more) the cells of the ring inside it whenever that
+// brings its cells closer to one unit wide.
+func newPolarLayout(rings int) *polarLayout {
+	l := &polarLayout{sizes: make([]int, rings), starts: make([]int, rings)}
+	l.sizes[0] = 1
+	for ring := 1; ring < rings; ring++ {
+		width := 2 * math.Pi * float64(ring) / float64(l.sizes[ring-1])
+		ratio := int(math.Round(width))
+		if ratio < 1 {
+			ratio = 1
+		}
+		l.sizes[ring] = l.sizes[ring-1] * ratio
+		l.starts[ring] = l.starts[ring-1] + l.sizes[ring-1]
+	}
+	return l
+}
+
+// CellCount implements the cellLayout interface
+func (l *polarLayout) CellCount() int {
+	last := len(l.sizes) - 1
+	return l.starts[last] + l.sizes[last]
+}
+
+// Degree implements the cellLayout interface: inward, clockwise and
+// counter-clockwise, plus one slot per cell of the next ring outside
+func (l *polarLayout) Degree(cell int) int {
+	return PolarOutward + l.outward(l.position(cell).Row)
+}
+
+// Neighbor implements the cellLayout interface
+func (l *polarLayout) Neighbor(cell, slot int) int {
+	pos := l.position(cell)
+	size := l.sizes[pos.Row]
+	switch {
+	case pos.Row == 0 && slot < PolarOutward:
+		return -1 // The centre has only outward neighbors
+	case slot == PolarInward:
+		return l.cell(Position{Row: pos.Row - 1, Col: pos.Col / l.outward(pos.Row-1)})
+	case slot == PolarClockwise:
+		return l.cell(Position{Row: pos.Row, Col: (pos.Col + 1) % size})
+	case slot == PolarCounterClockwise:
+		return l.cell(Position{Row: pos.Row, Col: (pos.Col - 1 + size) % size})
+	default:
+		outward := l.outward(pos.Row)
+		return l.cell(Position{Row: pos.Row + 1, Col: pos.Col*outward + slot - PolarOutward})
+	}
+}
+
+// reverse implements the cellLayout interface
+func (l *polarLayout) reverse(cell, slot int) int {
+	switch slot {
+	case PolarInward:
+		pos := l.position(cell)
+		return PolarOutward + pos.Col%l.outward(pos.Row-1)
+	case PolarClockwise:
+		return PolarCounterClockwise
+	case PolarCounterClockwise:
+		return PolarClockwise
+	default:
+		return PolarInward
+	}
+}
+
+// outward returns how many cells of the next ring out border each cell of a ring
+func (l *polarLayout) outward(ring int) int {
+	if ring == len(l.sizes)-1 {
+		return 0
+	}
+	return l.sizes[ring+1] / l.sizes[ring]
+}
+
+// cell numbers the cells ring by ring
+func (l *polarLayout) cell(pos Position) int {
+	return l.starts[pos.Row] + pos.Col
+}
+
+// position converts a cell number back to its ring and index
+func (l *polarLayout) position(cell int) Position {
+	ring := sort.Search(len(l.starts), func(i int) bool { return l.starts[i] > cell }) - 1
+	return Position{Row: ring, Col: cell - l.starts[ring]}
+}
@@ -0,0 +1,110 @@
+package maze
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestPolarLayout(t *testing.T) {
+	layout := newPolarLayout(8)
+	expected := []int{1, 6, 12, 24, 24, 24, 48, 48}
+	for ring, size := range expected {
+		if layout.sizes[ring] != size {
+			t.Errorf("Expected %d cells in ring %d, got %d", size, ring, layout.sizes[ring])
+		}
+	}
+
+	for cell := 0; cell < layout.CellCount(); cell++ {
+		if got := layout.cell(layout.position(cell)); got != cell {
+			t.Errorf("Cell %d maps to %v and back to %d", cell, layout.position(cell), got)
+		}
+	}
+
+	maze := NewPolarMaze(8)
+	tests := []struct {
+		pos      Position
+		slot     int
+		expected Position
+	}{
+		{Position{0, 0}, PolarOutward + 5, Position{1, 5}},
+		{Position{1, 5}, PolarClockwise, Position{1, 0}},
+		{Position{1, 0}, PolarCounterClockwise, Position{1, 5}},
+		{Position{2, 3}, PolarInward, Position{1, 1}},
+		{Position{2, 3}, PolarOutward + 1, Position{3, 7}},
+		{Position{4, 10}, PolarOutward, Position{5, 10}},
+	}
+	for _, tt := range tests {
+		if next, ok := maze.Neighbor(tt.pos, tt.slot); !ok || next != tt.expected {
+			t.Errorf("Neighbor(%v, %d) = %v, %v; expected %v", tt.pos, tt.slot, next, ok, tt.expected)
+		}
+	}
+	if _, ok := maze.Neighbor(Position{0, 0}, PolarInward); ok {
+		t.Error("Expected the centre to have no inward neighbor")
+	}
+	if degree := maze.Degree(Position{7, 0}); degree != PolarOutward {
+		t.Errorf("Expected the outer ring to have no outward slots, got degree %d", degree)
+	}
+}
+
+func TestGeneratePolarStart(t *testing.T) {
+	for _, seed := range []string{"1", "2", "3"} {
+		generator := NewGeneratorWithSeed(seed)
+		maze, err := generator.GeneratePolar(12)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if maze.Start.Row != 11 || maze.Goal != (Position{0, 0}) {
+			t.Errorf("Expected the start on the rim and the goal in the centre, got %v and %v", maze.Start, maze.Goal)
+		}
+
+		// No other cell of the rim is farther from the centre
+		distances := maze.graph.distances(0)
+		for col := 0; col < maze.RingSizes[11]; col++ {
+			if distances[maze.layout.cell(Position{Row: 11, Col: col})] > distances[maze.layout.cell(maze.Start)] {
+				t.Errorf("Seed %s: rim cell %d is farther from the centre than the start", seed, col)
+			}
+		}
+	}
+}
+
+func TestRenderPolar(t *testing.T) {
+	// The centre and the six cells around it, joined in a spiral
+	maze := NewPolarMaze(2)
+	maze.link(Position{0, 0}, PolarOutward)
+	for col := 0; col < 5; col++ {
+		maze.link(Position{1, col}, PolarClockwise)
+	}
+	maze.Start = Position{1, 5}
+	maze.Solve()
+	if len(maze.SolutionPath) != 7 {
+		t.Fatalf("Expected the solution to go all the way round, got %v", maze.SolutionPath)
+	}
+
+	svg := (&SVGRenderer{}).RenderPolar(maze)
+	walls := checkSVG(t, svg)
+	// Five arcs around the centre and five around the rim, one wall between cells
+	if arcs := strings.Count(walls, "A"); arcs != 10 {
+		t.Errorf("Expected 10 arcs, got %d: %s", arcs, walls)
+	}
+	if lines := strings.Count(walls, "L"); lines != 1 {
+		t.Errorf("Expected 1 straight wall, got %d: %s", lines, walls)
+	}
+	if !strings.Contains(svg, `width="100" height="100"`) {
+		t.Errorf("Expected a 100x100 drawing, got:\n%s", svg)
+	}
+
+	var parsed PolarJSON
+	if err := json.Unmarshal([]byte((&JSONRenderer{}).RenderPolar(maze)), &parsed); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	if parsed.Shape != "polar" || parsed.Rings != 2 || len(parsed.RingSizes) != 2 || len(parsed.Passages) != 6 ||
+		len(parsed.SolutionPath) != 7 {
+		t.Errorf("Unexpected JSON output: %+v", parsed)
+	}
+
+	renderer, _ := NewRenderer("ascii")
+	if _, ok := maze.Render(renderer); ok {
+		t.Error("Expected ASCII output not to support polar mazes")
+	}
+}
@@ -0,0 +1,63 @@
+package maze
+
+import "math/rand"
+
+// PrimAlgorithm implements maze generation using randomized Prim's algorithm
+type PrimAlgorithm struct{}
+
+// Generate implements the Algorithm interface using Prim's algorithm
+func (p *PrimAlgorithm) Generate(maze *Maze, startRow, startCol int, rng *rand.Rand) {
+	generateOnGrid(p, maze, startRow, startCol, rng)
+}
+
+// GenerateGraph implements the GraphAlgorithm interface. It grows the maze
+// from the starting cell by repeatedly picking a random frontier cell and
+// connecting it to a random neighbor already in the maze.
+func (p *PrimAlgorithm) GenerateGraph(graph Graph, start int, rng *rand.Rand) {
+	// Track which cells are in the maze, and which are waiting in the frontier list
+	inMaze := make([]bool, graph.CellCount())
+	inFrontier := make([]bool, graph.CellCount())
+
+	// Add the starting cell to the maze
+	inMaze[start] = true
+	frontier := p.addFrontier(graph, nil, inMaze, inFrontier, start)
+
+	for len(frontier) > 0 {
+		// Remove a random cell from the frontier
+		index := rng.Intn(len(frontier))
+		cell := frontier[index]
+		frontier[index] = frontier[len(frontier)-1]
+		frontier = frontier[:len(frontier)-1]
+
+		// Connect it to a random neighbor that is already part of the maze
+		slots := p.mazeNeighbors(graph, inMaze, cell)
+		inMaze[cell] = true
+		graph.Link(cell, slots[rng.Intn(len(slots))])
+
+		// Its unvisited neighbors become part of the frontier
+		frontier = p.addFrontier(graph, frontier, inMaze, inFrontier, cell)
+	}
+}
+
+// addFrontier appends the unvisited neighbors of a cell to the frontier list
+func (p *PrimAlgorithm) addFrontier(graph Graph, frontier []int, inMaze, inFrontier []bool, cell int) []int {
+	for slot := 0; slot < graph.Degree(cell); slot++ {
+		next := graph.Neighbor(cell, slot)
+		if next >= 0 && !inMaze[next] && !inFrontier[next] {
+			inFrontier[next] = true
+			frontier = append(frontier, next)
+		}
+	}
+	return frontier
+}
+
+// mazeNeighbors returns the slots of a cell that lead to neighbors already part of the maze
+func (p *PrimAlgorithm) mazeNeighbors(graph Graph, inMaze []bool, cell int) []int {
+	slots := make([]int, 0, graph.Degree(cell))
+	for slot := 0; slot < graph.Degree(cell); slot++ {
+		if next := graph.Neighbor(cell, slot); next >= 0 && inMaze[next] {
+			slots = append(slots, slot)
+		}
+	}
+	return slots
+}
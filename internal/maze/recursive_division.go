@@ -0,0 +1,74 @@
+package maze
+
+import "math/rand"
+
+// RecursiveDivisionAlgorithm implements maze generation using recursive division.
+// Unlike the passage carvers it starts from an open field and adds walls,
+// which gives long straight walls and a boxy, floor-plan look.
+type RecursiveDivisionAlgorithm struct{}
+
+// chamber is a rectangular region of cells that still has to be divided
+type chamber struct {
+	row, col      int // top-left cell
+	height, width int // size in cells
+}
+
+// Generate implements the Algorithm interface using recursive division.
+// Division always starts from the whole field, so the start position is ignored.
+func (d *RecursiveDivisionAlgorithm) Generate(maze *Maze, startRow, startCol int, rng *rand.Rand) {
+	// Open the whole interior, leaving only the boundary walls
+	for row := 1; row < maze.Height-1; row++ {
+		for col := 1; col < maze.Width-1; col++ {
+			maze.Grid[row][col] = false
+		}
+	}
+
+	// Divide chambers until every chamber is a single corridor. An explicit
+	// stack keeps deep divisions of very large mazes off the goroutine stack.
+	stack := []chamber{{row: 0, col: 0, height: (maze.Height - 1) / 2, width: (maze.Width - 1) / 2}}
+	for len(stack) > 0 {
+		c := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if c.height < 2 || c.width < 2 {
+			continue // A one-cell-wide corridor contains no loops
+		}
+
+		if d.chooseHorizontal(c, rng) {
+			// Wall below cell row split, with one gap
+			split := c.row + rng.Intn(c.height-1)
+			gap := c.col + rng.Intn(c.width)
+			wallRow := 2*split + 2
+			for col := 2*c.col + 1; col <= 2*(c.col+c.width-1)+1; col++ {
+				maze.Grid[wallRow][col] = col != 2*gap+1
+			}
+			stack = append(stack,
+				chamber{row: c.row, col: c.col, height: split - c.row + 1, width: c.width},
+				chamber{row: split + 1, col: c.col, height: c.row + c.height - split - 1, width: c.width},
+			)
+		} else {
+			// Wall right of cell column split, with one gap
+			split := c.col + rng.Intn(c.width-1)
+			gap := c.row + rng.Intn(c.height)
+			wallCol := 2*split + 2
+			for row := 2*c.row + 1; row <= 2*(c.row+c.height-1)+1; row++ {
+				maze.Grid[row][wallCol] = row != 2*gap+1
+			}
+			stack = append(stack,
+				chamber{row: c.row, col: c.col, height: c.height, width: split - c.col + 1},
+				chamber{row: c.row, col: split + 1, height: c.height, width: c.col + c.width - split - 1},
+			)
+		}
+	}
+}
+
+// chooseHorizontal decides the wall orientation, cutting across the longer side
+func (d *RecursiveDivisionAlgorithm) chooseHorizontal(c chamber, rng *rand.Rand) bool {
+	if c.height > c.width {
+		return true
+	}
+	if c.width > c.height {
+		return false
+	}
+	return rng.Intn(2) == 0
+}
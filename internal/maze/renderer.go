@@ -9,6 +9,52 @@ type Renderer interface {
 	Render(maze *Maze) string
 }
 
+// RowRenderer is implemented by renderers that can render a maze one grid row at a
+// time, which allows streaming mazes that are too large to hold in memory.
+type RowRenderer interface {
+	// RenderRow renders grid row i of the maze. above and below are the
+	// neighboring grid rows, or nil at the top and bottom edges.
+	RenderRow(maze *Maze, i int, above, row, below []bool) string
+}
+
+// HexRenderer is implemented by renderers that can draw hexagonal mazes.
+type HexRenderer interface {
+	RenderHex(maze *HexMaze) string
+}
+
+// PolarRenderer is implemented by renderers that can draw circular mazes.
+type PolarRenderer interface {
+	RenderPolar(maze *PolarMaze) string
+}
+
+// DeltaRenderer is implemented by renderers that can draw triangular mazes.
+type DeltaRenderer interface {
+	RenderDelta(maze *DeltaMaze) string
+}
+
+// UpsilonRenderer is implemented by renderers that can draw upsilon mazes.
+type UpsilonRenderer interface {
+	RenderUpsilon(maze *UpsilonMaze) string
+}
+
+// MultiLevelRenderer is implemented by renderers that can draw multi-level
+// mazes. Text renderers print the floors side by side when asked to, and one
+// after another otherwise; other formats ignore sideBySide.
+type MultiLevelRenderer interface {
+	RenderMultiLevel(maze *MultiLevelMaze, sideBySide bool) string
+}
+
+// CubeRenderer is implemented by renderers that can draw cube mazes.
+type CubeRenderer interface {
+	RenderCube(maze *CubeMaze) string
+}
+
+// GraphRenderer is implemented by renderers that can draw mazes over graphs
+// supplied by the user.
+type GraphRenderer interface {
+	RenderGraph(maze *GraphMaze) string
+}
+
 // NewRenderer creates a renderer based on the format name.
 func NewRenderer(format string) (Renderer, error) {
 	switch format {
@@ -18,6 +64,8 @@ func NewRenderer(format string) (Renderer, error) {
 		return &UnicodeRenderer{}, nil
 	case "json":
 		return &JSONRenderer{}, nil
+	case "svg":
+		return &SVGRenderer{}, nil
 	default:
 		return nil, fmt.Errorf("unsupported format: %s", format)
 	}
@@ -25,5 +73,5 @@ func NewRenderer(format string) (Renderer, error) {
 
 // GetSupportedFormats returns the list of supported output formats.
 func GetSupportedFormats() []string {
-	return []string{"ascii", "unicode", "json"}
+	return []string{"ascii", "unicode", "json", "svg"}
 }
@@ -2,6 +2,8 @@ package maze
 
 import (
 	"encoding/json"
+	"encoding/xml"
+	"io"
 	"strings"
 	"testing"
 	"unicode/utf8"
@@ -33,6 +35,12 @@ func TestNewRenderer(t *testing.T) {
 			expectError: false,
 			expectType:  "*maze.JSONRenderer",
 		},
+		{
+			name:        "SVG renderer",
+			format:      "svg",
+			expectError: false,
+			expectType:  "*maze.SVGRenderer",
+		},
 		{
 			name:        "Invalid format",
 			format:      "invalid",
@@ -67,7 +75,7 @@ func TestNewRenderer(t *testing.T) {
 // TestGetSupportedFormats tests the supported formats function
 func TestGetSupportedFormats(t *testing.T) {
 	formats := GetSupportedFormats()
-	expectedFormats := []string{"ascii", "unicode", "json"}
+	expectedFormats := []string{"ascii", "unicode", "json", "svg"}
 
 	if len(formats) != len(expectedFormats) {
 		t.Errorf("Expected %d formats, got %d", len(expectedFormats), len(formats))
@@ -203,6 +211,73 @@ func TestJSONRenderer(t *testing.T) {
 	}
 }
 
+// checkSVG verifies that the output is a well-formed SVG document and returns
+// the path data of its walls
+func checkSVG(t *testing.T, output string) string {
+	t.Helper()
+	decoder := xml.NewDecoder(strings.NewReader(output))
+	walls := ""
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Invalid SVG: %v\n%s", err, output)
+		}
+		if element, ok := token.(xml.StartElement); ok && element.Name.Local == "path" && walls == "" {
+			for _, attr := range element.Attr {
+				if attr.Name.Local == "d" {
+					walls = attr.Value
+				}
+			}
+		}
+	}
+	if !strings.HasPrefix(output, "<svg ") {
+		t.Errorf("Expected an <svg> root element, got:\n%s", output)
+	}
+	return walls
+}
+
+// TestSVGRenderer tests SVG output format
+func TestSVGRenderer(t *testing.T) {
+	maze := &Maze{
+		Width:    5,
+		Height:   5,
+		Grid:     createTestGrid(5, 5),
+		StartRow: 1,
+		StartCol: 1,
+		GoalRow:  3,
+		GoalCol:  3,
+	}
+
+	renderer := &SVGRenderer{}
+	output := renderer.Render(maze)
+	walls := checkSVG(t, output)
+
+	// Two cells of 20 pixels plus a margin of 10 pixels on either side
+	if !strings.Contains(output, `width="60" height="60"`) {
+		t.Errorf("Expected a 60x60 drawing, got:\n%s", output)
+	}
+	// The border has two wall segments on each side
+	if segments := strings.Count(walls, "M"); segments != 8 {
+		t.Errorf("Expected 8 wall segments, got %d: %s", segments, walls)
+	}
+	if strings.Count(output, "<circle") != 2 {
+		t.Error("Expected start and goal markers")
+	}
+	if strings.Contains(output, "#1e88e5") {
+		t.Error("Expected no solution route without a solution path")
+	}
+
+	maze.SolutionPath = []Position{{1, 1}, {1, 2}, {1, 3}, {2, 3}, {3, 3}}
+	output = renderer.Render(maze)
+	checkSVG(t, output)
+	if !strings.Contains(output, `d="M20 20L30 20L40 20L40 30L40 40"`) {
+		t.Errorf("Expected the solution route through the cell centers, got:\n%s", output)
+	}
+}
+
 // TestRendererWithSolutionPath tests renderers with solution path
 func TestRendererWithSolutionPath(t *testing.T) {
 	// Create a maze with solution path
@@ -510,6 +585,56 @@ func TestUnicodeRendererSnapshot(t *testing.T) {
 	}
 }
 
+// TestRendererSnapshotWithOpenings tests that border openings show up in every format
+func TestRendererSnapshotWithOpenings(t *testing.T) {
+	generator, err := NewGeneratorWithSeedAndAlgorithm("42", "dfs")
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+
+	maze, err := generator.GenerateWithOptions(7, 7, GenerateOptions{Entrance: SideTop, Exit: SideRight})
+	if err != nil {
+		t.Fatalf("Failed to generate maze: %v", err)
+	}
+	maze.SolutionPath = FindPath(maze)
+
+	expectedASCII := `#●#####
+#·#···#
+#·#·#·#
+#···#·#
+#####·#
+#    ·○
+#######
+`
+	if output := (&ASCIIRenderer{}).Render(maze); output != expectedASCII {
+		t.Errorf("ASCII openings snapshot failed.\nExpected:\n%s\nGot:\n%s", expectedASCII, output)
+	}
+
+	expectedUnicode := `╷◉┌───┐
+│•│•••│
+│•╵•╷•│
+│•••│•│
+├───┘•╵
+│    •◎
+└─────╴
+`
+	if output := (&UnicodeRenderer{}).Render(maze); output != expectedUnicode {
+		t.Errorf("Unicode openings snapshot failed.\nExpected:\n%s\nGot:\n%s", expectedUnicode, output)
+	}
+
+	var parsed JSON
+	if err := json.Unmarshal([]byte((&JSONRenderer{}).Render(maze)), &parsed); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	expectedOpenings := []Position{{Row: 0, Col: 1}, {Row: 5, Col: 6}}
+	if len(parsed.Openings) != 2 || parsed.Openings[0] != expectedOpenings[0] || parsed.Openings[1] != expectedOpenings[1] {
+		t.Errorf("Expected openings %v, got %v", expectedOpenings, parsed.Openings)
+	}
+	if parsed.Grid[0][1] || parsed.Grid[5][6] {
+		t.Error("Expected the openings to be open in the JSON grid")
+	}
+}
+
 // TestUnicodeRendererSnapshotNoSolution tests Unicode format without solution path
 func TestUnicodeRendererSnapshotNoSolution(t *testing.T) {
 	// Generate maze with fixed seed for consistent output
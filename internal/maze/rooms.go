@@ -0,0 +1,271 @@
+package maze
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// Rect is a rectangle of cells in grid coordinates: (Top, Left) is its
+// top-left cell and (Bottom, Right) its bottom-right cell, so all four are odd
+type Rect struct {
+	Top    int `json:"top"`
+	Left   int `json:"left"`
+	Bottom int `json:"bottom"`
+	Right  int `json:"right"`
+}
+
+// ParseRect parses a rectangle such as "3,5,7,11" (top,left,bottom,right in grid coordinates)
+func ParseRect(s string) (Rect, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) == 4 {
+		values := make([]int, 4)
+		valid := true
+		for i, part := range parts {
+			value, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil {
+				valid = false
+				break
+			}
+			values[i] = value
+		}
+		if valid {
+			return Rect{Top: values[0], Left: values[1], Bottom: values[2], Right: values[3]}, nil
+		}
+	}
+	return Rect{}, fmt.Errorf("invalid rectangle: %q (expected top,left,bottom,right)", s)
+}
+
+// String returns the rectangle in the form accepted by ParseRect
+func (r Rect) String() string {
+	return fmt.Sprintf("%d,%d,%d,%d", r.Top, r.Left, r.Bottom, r.Right)
+}
+
+// Contains reports whether the grid position lies inside the rectangle
+func (r Rect) Contains(pos Position) bool {
+	return pos.Row >= r.Top && pos.Row <= r.Bottom && pos.Col >= r.Left && pos.Col <= r.Right
+}
+
+// overlaps reports whether the two rectangles share a cell
+func (r Rect) overlaps(other Rect) bool {
+	return r.Top <= other.Bottom && other.Top <= r.Bottom && r.Left <= other.Right && other.Left <= r.Right
+}
+
+// validate checks that the corners are cells in the right order
+func (r Rect) validate() error {
+	if r.Top < 1 || r.Left < 1 || r.Top%2 == 0 || r.Left%2 == 0 || r.Bottom%2 == 0 || r.Right%2 == 0 {
+		return fmt.Errorf("rectangle %s must have odd, positive corners", r)
+	}
+	if r.Bottom < r.Top || r.Right < r.Left {
+		return fmt.Errorf("rectangle %s must have its top-left corner first", r)
+	}
+	return nil
+}
+
+// Room is an open rectangular area of a generated maze
+type Room struct {
+	Rect
+	Doors []Position `json:"doors"` // Open passages through the room's wall, in row-major order
+}
+
+// roomConstraint is a room reserved with Generator.AddRoom
+type roomConstraint struct {
+	rect  Rect
+	doors int
+}
+
+// AddRoom reserves an open rectangular room for the mazes generated from now
+// on. The algorithm carves around the room, which is then joined to the maze
+// through at least doors doors; more than one door creates loops. A room that
+// cuts the maze apart gets a door into every part it touches.
+func (g *Generator) AddRoom(rect Rect, doors int) error {
+	if err := rect.validate(); err != nil {
+		return fmt.Errorf("invalid room: %w", err)
+	}
+	if doors < 1 {
+		return fmt.Errorf("room %s needs at least 1 door, got %d", rect, doors)
+	}
+	g.rooms = append(g.rooms, roomConstraint{rect: rect, doors: doors})
+	return nil
+}
+
+// AddObstacle marks a rectangle of cells that must stay solid wall in the
+// mazes generated from now on. The algorithm carves around it.
+func (g *Generator) AddObstacle(rect Rect) error {
+	if err := rect.validate(); err != nil {
+		return fmt.Errorf("invalid obstacle: %w", err)
+	}
+	g.obstacles = append(g.obstacles, rect)
+	return nil
+}
+
+// checkObstacles rejects a fixed start or goal inside an obstacle, which
+// would otherwise only be found to be a wall
+func (g *Generator) checkObstacles(start, goal Placement) error {
+	placements := [2]Placement{start, goal}
+	for i, name := range [2]string{"start", "goal"} {
+		pos := Position{Row: placements[i].Row, Col: placements[i].Col}
+		if placements[i].Mode != PlacementFixed {
+			continue
+		}
+		for _, obstacle := range g.obstacles {
+			if obstacle.Contains(pos) {
+				return fmt.Errorf("%s %d,%d is inside obstacle %s", name, pos.Row, pos.Col, obstacle)
+			}
+		}
+	}
+	return nil
+}
+
+// ClearConstraints removes every room and obstacle added to the generator
+func (g *Generator) ClearConstraints() {
+	g.rooms = nil
+	g.obstacles = nil
+}
+
+// hasConstraints reports whether any rooms or obstacles were added
+func (g *Generator) hasConstraints() bool {
+	return len(g.rooms) > 0 || len(g.obstacles) > 0
+}
+
+// constraintMasks returns the cells left open by the obstacles (and the mask,
+// if any) and, within those, the cells left for the algorithm once the rooms
+// are taken out. It fails if a constraint does not fit the maze.
+func (g *Generator) constraintMasks(width, height int, mask *Mask) (*Mask, *Mask, error) {
+	var open *Mask
+	if mask != nil {
+		open = mask.clone()
+	} else {
+		open = NewMask((height-1)/2, (width-1)/2)
+	}
+
+	inside := func(rect Rect) bool { return rect.Bottom <= height-2 && rect.Right <= width-2 }
+	for _, obstacle := range g.obstacles {
+		if !inside(obstacle) {
+			return nil, nil, fmt.Errorf("obstacle %s does not fit in a %dx%d maze", obstacle, width, height)
+		}
+		open.disableRect(obstacle)
+	}
+
+	carvable := open.clone()
+	for i, room := range g.rooms {
+		if !inside(room.rect) {
+			return nil, nil, fmt.Errorf("room %s does not fit in a %dx%d maze", room.rect, width, height)
+		}
+		for _, obstacle := range g.obstacles {
+			if room.rect.overlaps(obstacle) {
+				return nil, nil, fmt.Errorf("room %s overlaps obstacle %s", room.rect, obstacle)
+			}
+		}
+		for _, other := range g.rooms[:i] {
+			if room.rect.overlaps(other.rect) {
+				return nil, nil, fmt.Errorf("room %s overlaps room %s", room.rect, other.rect)
+			}
+		}
+		for row := room.rect.Top; row <= room.rect.Bottom; row += 2 {
+			for col := room.rect.Left; col <= room.rect.Right; col += 2 {
+				if !open.Enabled((row-1)/2, (col-1)/2) {
+					return nil, nil, fmt.Errorf("room %s covers the masked-out cell %d,%d", room.rect, row, col)
+				}
+			}
+		}
+		carvable.disableRect(room.rect)
+	}
+
+	if err := open.validate(); err != nil {
+		return nil, nil, fmt.Errorf("obstacles must leave the open cells connected: %w", err)
+	}
+	if first, _ := carvable.maskCells(); first.Row == 0 {
+		return nil, nil, fmt.Errorf("rooms must leave at least one cell for the maze around them")
+	}
+	return open, carvable, nil
+}
+
+// carveRooms opens the reserved rooms of a maze generated around them and
+// knocks out doors until every room is joined to the maze and has at least
+// its requested number of doors. The open cells are already in maze.layout.
+func carveRooms(maze *Maze, rooms []roomConstraint, rng *rand.Rand) {
+	for _, room := range rooms {
+		for row := room.rect.Top; row <= room.rect.Bottom; row++ {
+			for col := room.rect.Left; col <= room.rect.Right; col++ {
+				maze.Grid[row][col] = false
+			}
+		}
+	}
+
+	// Each room is now one group of cells, so joining the groups gives every
+	// room its first door, or one per part of the maze that it touches
+	applyMask(maze, rng)
+
+	for _, room := range rooms {
+		var closed []Position
+		doors := 0
+		for _, door := range roomWall(maze, room.rect) {
+			if maze.Grid[door.Row][door.Col] {
+				closed = append(closed, door)
+			} else {
+				doors++
+			}
+		}
+		rng.Shuffle(len(closed), func(i, j int) { closed[i], closed[j] = closed[j], closed[i] })
+		for _, door := range closed {
+			if doors >= room.doors {
+				break
+			}
+			maze.Grid[door.Row][door.Col] = false
+			doors++
+		}
+	}
+}
+
+// roomWall returns the passages through a room's wall that lead to an open
+// cell, in row-major order; the open ones are the room's doors
+func roomWall(maze *Maze, rect Rect) []Position {
+	var wall []Position
+	add := func(row, col, dRow, dCol int) {
+		neighbor := Position{Row: row + dRow, Col: col + dCol}
+		if neighbor.Row > 0 && neighbor.Row < maze.Height-1 && neighbor.Col > 0 && neighbor.Col < maze.Width-1 &&
+			maze.cellEnabled(neighbor.Row, neighbor.Col) {
+			wall = append(wall, Position{Row: row + dRow/2, Col: col + dCol/2})
+		}
+	}
+
+	for col := rect.Left; col <= rect.Right; col += 2 {
+		add(rect.Top, col, -2, 0)
+	}
+	for row := rect.Top; row <= rect.Bottom; row += 2 {
+		add(row, rect.Left, 0, -2)
+		add(row, rect.Right, 0, 2)
+	}
+	for col := rect.Left; col <= rect.Right; col += 2 {
+		add(rect.Bottom, col, 2, 0)
+	}
+	return wall
+}
+
+// describeRooms lists the rooms of a finished maze with the doors they ended
+// up with, after braiding or sparseness may have changed them
+func describeRooms(maze *Maze, rooms []roomConstraint) []Room {
+	described := make([]Room, 0, len(rooms))
+	for _, room := range rooms {
+		doors := make([]Position, 0)
+		for _, door := range roomWall(maze, room.rect) {
+			if !maze.Grid[door.Row][door.Col] {
+				doors = append(doors, door)
+			}
+		}
+		described = append(described, Room{Rect: room.rect, Doors: doors})
+	}
+	return described
+}
+
+// inRoom reports whether the grid position lies inside one of the maze's rooms
+func (m *Maze) inRoom(pos Position) bool {
+	for _, room := range m.Rooms {
+		if room.Contains(pos) {
+			return true
+		}
+	}
+	return false
+}
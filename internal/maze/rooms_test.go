@@ -0,0 +1,348 @@
+package maze
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestParseRect(t *testing.T) {
+	rect, err := ParseRect(" 3, 5 ,7,11")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if rect != (Rect{Top: 3, Left: 5, Bottom: 7, Right: 11}) {
+		t.Errorf("Unexpected rectangle %+v", rect)
+	}
+	if rect.String() != "3,5,7,11" {
+		t.Errorf("Expected String() to round-trip, got %q", rect.String())
+	}
+
+	for _, input := range []string{"", "3,5,7", "3,5,7,11,13", "a,5,7,11"} {
+		if _, err := ParseRect(input); err == nil {
+			t.Errorf("Expected error for %q", input)
+		}
+	}
+}
+
+func TestAddRoomAndObstacleErrors(t *testing.T) {
+	generator := NewGeneratorWithSeed("1")
+
+	for _, rect := range []Rect{{2, 1, 3, 3}, {1, 1, 3, 4}, {-1, 1, 3, 3}, {5, 1, 3, 3}, {1, 5, 3, 3}} {
+		if err := generator.AddRoom(rect, 1); err == nil {
+			t.Errorf("Expected error for room %s", rect)
+		}
+		if err := generator.AddObstacle(rect); err == nil {
+			t.Errorf("Expected error for obstacle %s", rect)
+		}
+	}
+	if err := generator.AddRoom(Rect{1, 1, 3, 3}, 0); err == nil {
+		t.Error("Expected error for a room without doors")
+	}
+	if generator.hasConstraints() {
+		t.Error("Rejected constraints should not be added")
+	}
+}
+
+// checkRoomMaze verifies that obstacles are solid, rooms are open, and that the
+// maze is a perfect maze once every room is counted as a single cell
+func checkRoomMaze(t *testing.T, maze *Maze, obstacles []Rect, perfect bool) {
+	t.Helper()
+
+	for _, obstacle := range obstacles {
+		for row := obstacle.Top - 1; row <= obstacle.Bottom+1; row++ {
+			for col := obstacle.Left - 1; col <= obstacle.Right+1; col++ {
+				corner := (row == obstacle.Top-1 || row == obstacle.Bottom+1) && (col == obstacle.Left-1 || col == obstacle.Right+1)
+				if !corner && !maze.Grid[row][col] {
+					t.Fatalf("Expected obstacle %s to be solid, got a path at %d,%d", obstacle, row, col)
+				}
+			}
+		}
+	}
+	for _, room := range maze.Rooms {
+		for row := room.Top; row <= room.Bottom; row++ {
+			for col := room.Left; col <= room.Right; col++ {
+				if maze.Grid[row][col] {
+					t.Fatalf("Expected room %s to be open, got a wall at %d,%d", room.Rect, row, col)
+				}
+			}
+		}
+		if len(room.Doors) == 0 {
+			t.Fatalf("Expected room %s to have a door", room.Rect)
+		}
+	}
+
+	visited := make([][]bool, maze.Height)
+	for i := range visited {
+		visited[i] = make([]bool, maze.Width)
+	}
+	floodFill(maze, visited, maze.StartRow, maze.StartCol)
+
+	cells, passages := len(maze.Rooms), 0
+	for row := 1; row < maze.Height-1; row++ {
+		for col := 1; col < maze.Width-1; col++ {
+			if maze.Grid[row][col] {
+				continue
+			}
+			if !visited[row][col] {
+				t.Fatalf("Open position %d,%d is not reachable from the start", row, col)
+			}
+			if maze.inRoom(Position{Row: row, Col: col}) {
+				continue
+			}
+			if row%2 == 1 && col%2 == 1 {
+				cells++
+			} else {
+				passages++
+			}
+		}
+	}
+	if perfect && passages != cells-1 {
+		t.Errorf("Expected %d passages between %d cells and rooms, got %d", cells-1, cells, passages)
+	}
+}
+
+func TestRoomsAndObstacles(t *testing.T) {
+	obstacles := []Rect{{Top: 13, Left: 3, Bottom: 15, Right: 9}, {Top: 1, Left: 19, Bottom: 1, Right: 19}}
+	rooms := []Rect{{Top: 3, Left: 5, Bottom: 7, Right: 11}, {Top: 13, Left: 13, Bottom: 13, Right: 15}}
+
+	for _, name := range GetSupportedAlgorithms() {
+		t.Run(name, func(t *testing.T) {
+			for _, seed := range []string{"1", "2", "3"} {
+				generator, _ := NewGeneratorWithSeedAndAlgorithm(seed, name)
+				for _, rect := range rooms {
+					if err := generator.AddRoom(rect, 1); err != nil {
+						t.Fatal(err)
+					}
+				}
+				for _, rect := range obstacles {
+					if err := generator.AddObstacle(rect); err != nil {
+						t.Fatal(err)
+					}
+				}
+
+				maze, err := generator.GenerateWithOptions(21, 19, GenerateOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				checkRoomMaze(t, maze, obstacles, true)
+
+				if len(maze.Rooms) != 2 {
+					t.Fatalf("Expected 2 rooms, got %d", len(maze.Rooms))
+				}
+				for i, room := range maze.Rooms {
+					if room.Rect != rooms[i] {
+						t.Errorf("Expected room %s, got %s", rooms[i], room.Rect)
+					}
+					if len(room.Doors) != 1 {
+						t.Errorf("Expected room %s to have 1 door, got %v", room.Rect, room.Doors)
+					}
+				}
+				if maze.GoalRow != 17 || maze.GoalCol != 19 {
+					t.Errorf("Expected the goal at 17,19, got %d,%d", maze.GoalRow, maze.GoalCol)
+				}
+			}
+		})
+	}
+}
+
+func TestRoomDoors(t *testing.T) {
+	generator, _ := NewGeneratorWithSeedAndAlgorithm("7", "kruskal")
+	if err := generator.AddRoom(Rect{Top: 7, Left: 7, Bottom: 11, Right: 11}, 4); err != nil {
+		t.Fatal(err)
+	}
+	maze := generator.Generate(21, 21)
+	checkRoomMaze(t, maze, nil, false)
+
+	doors := maze.Rooms[0].Doors
+	if len(doors) != 4 {
+		t.Fatalf("Expected 4 doors, got %v", doors)
+	}
+	for i, door := range doors {
+		onWall := door.Row == 6 || door.Row == 12 || door.Col == 6 || door.Col == 12
+		if !onWall || maze.Grid[door.Row][door.Col] {
+			t.Errorf("Door %v is not an open passage through the room's wall", door)
+		}
+		if i > 0 && (door.Row < doors[i-1].Row || door.Row == doors[i-1].Row && door.Col < doors[i-1].Col) {
+			t.Errorf("Expected doors in row-major order, got %v", doors)
+		}
+	}
+
+	// A full-height room splits the maze, so it needs a door on both sides
+	generator, _ = NewGeneratorWithSeedAndAlgorithm("7", "dfs")
+	if err := generator.AddRoom(Rect{Top: 1, Left: 9, Bottom: 19, Right: 11}, 1); err != nil {
+		t.Fatal(err)
+	}
+	maze = generator.Generate(21, 21)
+	checkRoomMaze(t, maze, nil, true)
+	left, right := false, false
+	for _, door := range maze.Rooms[0].Doors {
+		left = left || door.Col == 8
+		right = right || door.Col == 12
+	}
+	if !left || !right {
+		t.Errorf("Expected doors on both sides of the splitting room, got %v", maze.Rooms[0].Doors)
+	}
+}
+
+func TestRoomsWithOptions(t *testing.T) {
+	generator, _ := NewGeneratorWithSeedAndAlgorithm("3", "dfs")
+	room := Rect{Top: 9, Left: 9, Bottom: 11, Right: 11}
+	if err := generator.AddRoom(room, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	// Sparseness fills in dead ends but never the room itself
+	maze, err := generator.GenerateWithOptions(21, 21, GenerateOptions{Sparse: 100})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	checkRoomMaze(t, maze, nil, true)
+
+	// Braiding can add doors, which are reported afterwards
+	maze, err = generator.GenerateWithOptions(21, 21, GenerateOptions{Braid: 1})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	checkRoomMaze(t, maze, nil, false)
+	if len(DeadEnds(maze)) != 0 {
+		t.Error("Expected no dead ends after full braiding")
+	}
+
+	// Rooms and obstacles work inside a mask too
+	mask, _ := ParseMaskText(strings.NewReader(heartMask))
+	generator, _ = NewGeneratorWithSeedAndAlgorithm("3", "wilson")
+	if err := generator.AddRoom(Rect{Top: 5, Left: 5, Bottom: 7, Right: 9}, 2); err != nil {
+		t.Fatal(err)
+	}
+	if err := generator.AddObstacle(Rect{Top: 3, Left: 13, Bottom: 5, Right: 15}); err != nil {
+		t.Fatal(err)
+	}
+	maze, err = generator.GenerateWithOptions(mask.GridWidth(), mask.GridHeight(), GenerateOptions{Mask: mask})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	checkRoomMaze(t, maze, []Rect{{Top: 3, Left: 13, Bottom: 5, Right: 15}}, false)
+	if !maze.Grid[1][1] || maze.Mask != mask {
+		t.Error("Expected the mask to be kept")
+	}
+
+	var parsed JSON
+	if err := json.Unmarshal([]byte((&JSONRenderer{}).Render(maze)), &parsed); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	if len(parsed.Rooms) != 1 || parsed.Rooms[0].Rect != (Rect{Top: 5, Left: 5, Bottom: 7, Right: 9}) || len(parsed.Rooms[0].Doors) < 2 {
+		t.Errorf("Unexpected rooms in JSON output: %+v", parsed.Rooms)
+	}
+}
+
+func TestRoomsJSON(t *testing.T) {
+	generator, _ := NewGeneratorWithSeedAndAlgorithm("7", "kruskal")
+	room := Rect{Top: 7, Left: 7, Bottom: 11, Right: 11}
+	if err := generator.AddRoom(room, 3); err != nil {
+		t.Fatal(err)
+	}
+	maze := generator.Generate(21, 21)
+
+	// Decode the keys as written, so that a change of casing shows up
+	var parsed struct {
+		Grid  [][]bool                     `json:"grid"`
+		Rooms []map[string]json.RawMessage `json:"rooms"`
+	}
+	if err := json.Unmarshal([]byte((&JSONRenderer{}).Render(maze)), &parsed); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	if len(parsed.Rooms) != 1 {
+		t.Fatalf("Expected 1 room, got %d", len(parsed.Rooms))
+	}
+	var doors []map[string]int
+	if err := json.Unmarshal(parsed.Rooms[0]["doors"], &doors); err != nil {
+		t.Fatalf("Failed to parse doors: %v", err)
+	}
+	if len(doors) != len(maze.Rooms[0].Doors) {
+		t.Fatalf("Expected %d doors, got %v", len(maze.Rooms[0].Doors), doors)
+	}
+	for i, door := range doors {
+		row, col := door["row"], door["col"]
+		if len(door) != 2 || (Position{Row: row, Col: col}) != maze.Rooms[0].Doors[i] {
+			t.Errorf("Expected door %v with lowercase row and col keys, got %v", maze.Rooms[0].Doors[i], door)
+		}
+		onWall := row == room.Top-1 || row == room.Bottom+1 || col == room.Left-1 || col == room.Right+1
+		if !onWall || parsed.Grid[row][col] {
+			t.Errorf("Door %v is not an open passage through the room's wall in the grid", door)
+		}
+	}
+	for _, key := range []string{"top", "left", "bottom", "right"} {
+		if _, ok := parsed.Rooms[0][key]; !ok {
+			t.Errorf("Expected key %q in room %v", key, parsed.Rooms[0])
+		}
+	}
+}
+
+func TestRoomsAndObstaclesErrors(t *testing.T) {
+	mask, _ := ParseMaskText(strings.NewReader(heartMask))
+
+	tests := []struct {
+		name      string
+		rooms     []Rect
+		obstacles []Rect
+		mask      *Mask
+		goal      Placement
+		errMsg    string
+	}{
+		{name: "room too large", rooms: []Rect{{1, 1, 21, 3}}, errMsg: "does not fit"},
+		{name: "obstacle too large", obstacles: []Rect{{1, 19, 3, 21}}, errMsg: "does not fit"},
+		{name: "rooms overlap", rooms: []Rect{{1, 1, 5, 5}, {5, 5, 7, 7}}, errMsg: "overlaps room"},
+		{name: "room on obstacle", rooms: []Rect{{1, 1, 5, 5}}, obstacles: []Rect{{3, 3, 3, 3}}, errMsg: "overlaps obstacle"},
+		{name: "obstacle splits maze", obstacles: []Rect{{9, 1, 9, 19}}, errMsg: "connected"},
+		{name: "rooms fill maze", rooms: []Rect{{1, 1, 19, 9}, {1, 11, 19, 19}}, errMsg: "at least one cell"},
+		{name: "room outside mask", rooms: []Rect{{1, 1, 3, 3}}, mask: mask, errMsg: "masked-out cell"},
+		{name: "goal in obstacle", obstacles: []Rect{{5, 5, 7, 7}}, goal: Placement{Mode: PlacementFixed, Row: 7, Col: 5}, errMsg: "goal 7,5 is inside obstacle 5,5,7,7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generator := NewGeneratorWithSeed("1")
+			for _, rect := range tt.rooms {
+				if err := generator.AddRoom(rect, 1); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for _, rect := range tt.obstacles {
+				if err := generator.AddObstacle(rect); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			width, height := 21, 21
+			if tt.mask != nil {
+				width, height = tt.mask.GridWidth(), tt.mask.GridHeight()
+			}
+			_, err := generator.GenerateWithOptions(width, height, GenerateOptions{Mask: tt.mask, Goal: tt.goal})
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("Expected error containing %q, got %v", tt.errMsg, err)
+			}
+		})
+	}
+}
+
+func TestClearConstraints(t *testing.T) {
+	plain := NewGeneratorWithSeed("42").Generate(21, 21)
+
+	generator := NewGeneratorWithSeed("42")
+	if err := generator.AddObstacle(Rect{Top: 5, Left: 5, Bottom: 9, Right: 9}); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := generator.Stream(&buf, 21, 21, "ascii"); err == nil {
+		t.Error("Expected error streaming a maze with constraints")
+	}
+
+	generator.ClearConstraints()
+	maze := generator.Generate(21, 21)
+	if maze.String() != plain.String() || maze.Rooms != nil {
+		t.Error("Expected a generator without constraints to generate the usual maze")
+	}
+}
@@ -0,0 +1,89 @@
+package maze
+
+import "math/rand"
+
+// SidewinderAlgorithm implements maze generation using the Sidewinder algorithm.
+// Each row is split into horizontal runs toward the bias side, and every run is
+// closed by carving one passage toward the bias row from a random cell in it.
+type SidewinderAlgorithm struct {
+	bias Bias
+}
+
+// NewSidewinderAlgorithm creates a Sidewinder algorithm leaning toward the given
+// bias corner (NE, NW, SE or SW). An empty string selects DefaultBias.
+func NewSidewinderAlgorithm(bias string) (*SidewinderAlgorithm, error) {
+	b, err := ParseBias(bias)
+	if err != nil {
+		return nil, err
+	}
+	return &SidewinderAlgorithm{bias: b}, nil
+}
+
+// Bias returns the corner the algorithm leans toward
+func (s *SidewinderAlgorithm) Bias() Bias {
+	return s.bias
+}
+
+// Generate implements the Algorithm interface using the Sidewinder algorithm.
+// Cells are processed in row order, so the start position is ignored.
+func (s *SidewinderAlgorithm) Generate(maze *Maze, startRow, startCol int, rng *rand.Rand) {
+	generateFromRows(s, maze, rng)
+}
+
+// GenerateRows implements the RowGenerator interface.
+// Each grid row is emitted as a freshly allocated slice, from top to bottom.
+func (s *SidewinderAlgorithm) GenerateRows(width, height int, rng *rand.Rand, emit func(row []bool) error) error {
+	cellCols := (width - 1) / 2
+	cellRows := (height - 1) / 2
+	rowStep, colStep := s.bias.rowStep(), s.bias.colStep()
+
+	// Runs extend toward the bias side, so walk the row starting from the other side
+	firstCol := 0
+	if colStep < 0 {
+		firstCol = cellCols - 1
+	}
+
+	// above is the grid row between the previous row of cells and the current one
+	above := newWallRow(width)
+	run := make([]int, 0, cellCols)
+	for r := 0; r < cellRows; r++ {
+		cellRow := newWallRow(width)
+		below := newWallRow(width)
+		canCarveVertical := r+rowStep >= 0 && r+rowStep < cellRows
+
+		run = run[:0]
+		for i, c := 0, firstCol; i < cellCols; i, c = i+1, c+colStep {
+			cellRow[2*c+1] = false
+			run = append(run, c)
+
+			atBoundary := i == cellCols-1
+			closeRun := atBoundary || (canCarveVertical && rng.Intn(2) == 0)
+			if !closeRun {
+				cellRow[2*c+1+colStep] = false
+				continue
+			}
+
+			// Close the run with a single passage toward the bias row
+			if canCarveVertical {
+				member := run[rng.Intn(len(run))]
+				if rowStep < 0 {
+					above[2*member+1] = false
+				} else {
+					below[2*member+1] = false
+				}
+			}
+			run = run[:0]
+		}
+
+		if err := emit(above); err != nil {
+			return err
+		}
+		if err := emit(cellRow); err != nil {
+			return err
+		}
+		above = below
+	}
+
+	// Bottom boundary
+	return emit(above)
+}
@@ -0,0 +1,36 @@
+package maze
+
+import "fmt"
+
+// Sparsify fills in dead ends to leave solid areas of rock between fewer
+// corridors. Each pass turns every current dead-end cell, together with its
+// single open passage, back into wall, so n passes shorten every dead-end
+// corridor by up to n cells. The start, the goal and rooms are never filled,
+// which means the route between the start and goal is never cut. Passes stop
+// early once no dead ends are left to fill.
+func Sparsify(maze *Maze, passes int) error {
+	if passes < 0 {
+		return fmt.Errorf("sparse passes must not be negative, got %d", passes)
+	}
+
+	start := Position{Row: maze.StartRow, Col: maze.StartCol}
+	goal := Position{Row: maze.GoalRow, Col: maze.GoalCol}
+
+	for pass := 0; pass < passes; pass++ {
+		filled := false
+		for _, cell := range DeadEnds(maze) {
+			if cell == start || cell == goal || maze.inRoom(cell) {
+				continue
+			}
+			for _, dir := range cellDirections {
+				maze.setWall(Position{Row: cell.Row + dir.Row/2, Col: cell.Col + dir.Col/2}, true)
+			}
+			maze.Grid[cell.Row][cell.Col] = true
+			filled = true
+		}
+		if !filled {
+			break
+		}
+	}
+	return nil
+}
@@ -0,0 +1,466 @@
+// Package maze provides maze generation and representation functionality.
+// This file implements SVG rendering for printable maze output.
+package maze
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SVGRenderer renders mazes as scalable vector graphics with thin walls,
+// suitable for printing. Solid cells are filled grey, the solution is drawn
+// as a line and the start and goal as green and red dots.
+type SVGRenderer struct{}
+
+const (
+	svgCellSize = 20 // Side of a square cell in pixels
+	svgHexSide  = 12 // Side of a hexagonal cell in pixels
+	svgTriangle = 24 // Side of a triangular cell in pixels
+	svgOctagon  = 12 // Side of an octagonal cell, and of the squares between them, in pixels
+	svgMargin   = 10 // Blank space around the maze in pixels
+)
+
+// svgPoint is a point in SVG user coordinates
+type svgPoint struct {
+	X, Y float64
+}
+
+// svgWriter collects the elements of an SVG drawing
+type svgWriter struct {
+	fills    strings.Builder // Elements drawn beneath the walls
+	walls    strings.Builder // Path data of every wall
+	solution strings.Builder // Path data of the solution route
+	markers  strings.Builder // Start and goal dots, drawn on top
+}
+
+// svgNumber formats a coordinate with at most two decimals
+func svgNumber(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
+
+// wall adds a straight wall from one point to another
+func (w *svgWriter) wall(from, to svgPoint) {
+	fmt.Fprintf(&w.walls, "M%s %sL%s %s", svgNumber(from.X), svgNumber(from.Y), svgNumber(to.X), svgNumber(to.Y))
+}
+
+// arc adds a wall along a circle of the given radius, clockwise from one point to another
+func (w *svgWriter) arc(from, to svgPoint, radius float64) {
+	fmt.Fprintf(&w.walls, "M%s %sA%s %s 0 0 1 %s %s", svgNumber(from.X), svgNumber(from.Y),
+		svgNumber(radius), svgNumber(radius), svgNumber(to.X), svgNumber(to.Y))
+}
+
+// fill adds a solid rectangle between two corners
+func (w *svgWriter) fill(from, to svgPoint) {
+	fmt.Fprintf(&w.fills, "<rect x=\"%s\" y=\"%s\" width=\"%s\" height=\"%s\" fill=\"#999\"/>\n",
+		svgNumber(from.X), svgNumber(from.Y), svgNumber(to.X-from.X), svgNumber(to.Y-from.Y))
+}
+
+// route adds the solution route through the points, lifting the pen before
+// point i wherever joined(i) reports that it does not continue from point i-1
+func (w *svgWriter) route(points []svgPoint, joined func(i int) bool) {
+	for i, p := range points {
+		command := "L"
+		if i == 0 || !joined(i) {
+			command = "M"
+		}
+		fmt.Fprintf(&w.solution, "%s%s %s", command, svgNumber(p.X), svgNumber(p.Y))
+	}
+}
+
+// bridge adds the walls of a crossing cell between two corners. The rails of
+// the bridge run across the cell, set in a little from its sides, and the
+// walls of the corridor passing under stop at them.
+func (w *svgWriter) bridge(from, to svgPoint, vertical bool) {
+	inset := svgCellSize / 5.0
+	if vertical {
+		w.wall(svgPoint{X: from.X + inset, Y: from.Y}, svgPoint{X: from.X + inset, Y: to.Y})
+		w.wall(svgPoint{X: to.X - inset, Y: from.Y}, svgPoint{X: to.X - inset, Y: to.Y})
+		for _, y := range []float64{from.Y, to.Y} {
+			w.wall(svgPoint{X: from.X, Y: y}, svgPoint{X: from.X + inset, Y: y})
+			w.wall(svgPoint{X: to.X - inset, Y: y}, svgPoint{X: to.X, Y: y})
+		}
+		return
+	}
+	w.wall(svgPoint{X: from.X, Y: from.Y + inset}, svgPoint{X: to.X, Y: from.Y + inset})
+	w.wall(svgPoint{X: from.X, Y: to.Y - inset}, svgPoint{X: to.X, Y: to.Y - inset})
+	for _, x := range []float64{from.X, to.X} {
+		w.wall(svgPoint{X: x, Y: from.Y}, svgPoint{X: x, Y: from.Y + inset})
+		w.wall(svgPoint{X: x, Y: to.Y - inset}, svgPoint{X: x, Y: to.Y})
+	}
+}
+
+// fold adds a dashed fold line from one point to another
+func (w *svgWriter) fold(from, to svgPoint) {
+	fmt.Fprintf(&w.fills, "<line x1=\"%s\" y1=\"%s\" x2=\"%s\" y2=\"%s\" stroke=\"#999\" stroke-dasharray=\"4 4\"/>\n",
+		svgNumber(from.X), svgNumber(from.Y), svgNumber(to.X), svgNumber(to.Y))
+}
+
+// marker adds a dot of the given color
+func (w *svgWriter) marker(center svgPoint, radius float64, color string) {
+	fmt.Fprintf(&w.markers, "<circle cx=\"%s\" cy=\"%s\" r=\"%s\" fill=\"%s\"/>\n",
+		svgNumber(center.X), svgNumber(center.Y), svgNumber(radius), color)
+}
+
+// svgSideDirections are the unit vectors pointing out of the maze through each side
+var svgSideDirections = map[Side]svgPoint{SideTop: {0, -1}, SideRight: {1, 0}, SideBottom: {0, 1}, SideLeft: {-1, 0}}
+
+// wrapMarker adds an arrowhead on a gap in the border, pointing out through
+// the given side, to show that the passage wraps around to the opposite side
+func (w *svgWriter) wrapMarker(gap svgPoint, side Side) {
+	d := svgSideDirections[side]
+	size := float64(svgCellSize) / 4
+	// The tip lies outside the border and the base inside, across the gap
+	tip := svgPoint{X: gap.X + d.X*size, Y: gap.Y + d.Y*size}
+	base := svgPoint{X: gap.X - d.X*size, Y: gap.Y - d.Y*size}
+	fmt.Fprintf(&w.markers, "<polygon points=\"%s,%s %s,%s %s,%s\" fill=\"#999\"/>\n",
+		svgNumber(tip.X), svgNumber(tip.Y),
+		svgNumber(base.X-d.Y*size), svgNumber(base.Y+d.X*size),
+		svgNumber(base.X+d.Y*size), svgNumber(base.Y-d.X*size))
+}
+
+// document returns the complete SVG document of the given size
+func (w *svgWriter) document(width, height float64) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%s\" height=\"%s\" viewBox=\"0 0 %s %s\">\n",
+		svgNumber(width), svgNumber(height), svgNumber(width), svgNumber(height))
+	sb.WriteString("<rect width=\"100%\" height=\"100%\" fill=\"white\"/>\n")
+	sb.WriteString(w.fills.String())
+	fmt.Fprintf(&sb, "<path d=\"%s\" fill=\"none\" stroke=\"black\" stroke-width=\"2\" stroke-linecap=\"round\"/>\n", w.walls.String())
+	if w.solution.Len() > 0 {
+		fmt.Fprintf(&sb, "<path d=\"%s\" fill=\"none\" stroke=\"#1e88e5\" stroke-width=\"3\" stroke-linecap=\"round\" stroke-linejoin=\"round\"/>\n", w.solution.String())
+	}
+	sb.WriteString(w.markers.String())
+	sb.WriteString("</svg>\n")
+	return sb.String()
+}
+
+// Render generates an SVG drawing of the maze. Walls between cells become
+// lines, and open border positions gaps: openings, and wrap-around passages,
+// which are marked with arrowheads pointing out of the maze. Crossings of
+// weave mazes are drawn as bridges.
+func (r *SVGRenderer) Render(m *Maze) string {
+	w := &svgWriter{}
+	w.grid(m)
+	return w.document(2*svgMargin+float64(m.Width-1)*svgCellSize/2, 2*svgMargin+float64(m.Height-1)*svgCellSize/2)
+}
+
+// svgGridPoint maps a grid position of a square maze to a point: cells to
+// their centers, and walls to the middle of the line between two cells
+func svgGridPoint(row, col int) svgPoint {
+	return svgPoint{X: svgMargin + float64(col)*svgCellSize/2, Y: svgMargin + float64(row)*svgCellSize/2}
+}
+
+// grid adds the walls, solution and markers of a square maze
+func (w *svgWriter) grid(m *Maze) {
+	at := svgGridPoint
+	for i := 0; i < m.Height; i++ {
+		for j := 0; j < m.Width; j++ {
+			if side := m.wrapSide(Position{Row: i, Col: j}); side != SideNone {
+				w.wrapMarker(at(i, j), side)
+			}
+			if crossing, ok := m.Crossings[Position{Row: i, Col: j}]; ok {
+				w.bridge(at(i-1, j-1), at(i+1, j+1), crossing == CrossingVertical)
+				continue
+			}
+			if !m.Grid[i][j] || m.outsideMask(i, j) {
+				continue
+			}
+			switch {
+			case i%2 == 1 && j%2 == 1:
+				w.fill(at(i-1, j-1), at(i+1, j+1)) // A solid cell, e.g. an obstacle
+			case i%2 == 0 && j%2 == 1:
+				w.wall(at(i, j-1), at(i, j+1))
+			case i%2 == 1 && j%2 == 0:
+				w.wall(at(i-1, j), at(i+1, j))
+			}
+		}
+	}
+
+	if len(m.SolutionPath) > 0 {
+		points := make([]svgPoint, len(m.SolutionPath))
+		for i, pos := range m.SolutionPath {
+			points[i] = at(pos.Row, pos.Col)
+		}
+		// Steps across a wrapping edge jump to the other side of the drawing
+		w.route(points, func(i int) bool {
+			return abs(m.SolutionPath[i].Row-m.SolutionPath[i-1].Row)+abs(m.SolutionPath[i].Col-m.SolutionPath[i-1].Col) == 1
+		})
+	}
+
+	w.marker(at(m.StartRow, m.StartCol), svgCellSize/4, "green")
+	w.marker(at(m.GoalRow, m.GoalCol), svgCellSize/4, "red")
+}
+
+// RenderHex implements the HexRenderer interface
+func (r *SVGRenderer) RenderHex(m *HexMaze) string {
+	w := &svgWriter{}
+	height := math.Sqrt(3) * svgHexSide // Distance between two opposite sides
+	center := func(pos Position) svgPoint {
+		return svgPoint{
+			X: svgMargin + svgHexSide + 1.5*svgHexSide*float64(pos.Col),
+			Y: svgMargin + height/2 + height*float64(pos.Row) + height/2*float64(pos.Col%2),
+		}
+	}
+	// Corner k of a hexagon lies at 60k degrees clockwise from its right-hand corner
+	corner := func(c svgPoint, k int) svgPoint {
+		angle := float64(k%6) * math.Pi / 3
+		return svgPoint{X: c.X + svgHexSide*math.Cos(angle), Y: c.Y + svgHexSide*math.Sin(angle)}
+	}
+
+	for row := 0; row < m.Rows; row++ {
+		for col := 0; col < m.Cols; col++ {
+			pos := Position{Row: row, Col: col}
+			c := center(pos)
+			for slot := 0; slot < hexSlots; slot++ {
+				// Shared walls are drawn once, from the cell below or left of them
+				_, hasNeighbor := m.Neighbor(pos, slot)
+				if m.Linked(pos, slot) || (hasNeighbor && slot > HexSouthEast) {
+					continue
+				}
+				w.wall(corner(c, slot+4), corner(c, slot+5))
+			}
+		}
+	}
+
+	if len(m.SolutionPath) > 0 {
+		points := make([]svgPoint, len(m.SolutionPath))
+		for i, pos := range m.SolutionPath {
+			points[i] = center(pos)
+		}
+		w.route(points, func(int) bool { return true })
+	}
+
+	w.marker(center(m.Start), svgHexSide/2, "green")
+	w.marker(center(m.Goal), svgHexSide/2, "red")
+	return w.document(2*svgMargin+svgHexSide*(1.5*float64(m.Cols)+0.5), 2*svgMargin+height*(float64(m.Rows)+0.5))
+}
+
+// RenderPolar implements the PolarRenderer interface. Each ring is one square
+// cell thick; the wall between two rings is drawn as arcs, and the outer rim
+// is left open at the start.
+func (r *SVGRenderer) RenderPolar(m *PolarMaze) string {
+	w := &svgWriter{}
+	radius := svgMargin + svgCellSize*float64(m.Rings)
+	// at returns the point at a distance from the centre, at a fraction of a turn clockwise from the top
+	at := func(distance, turn float64) svgPoint {
+		angle := 2*math.Pi*turn - math.Pi/2
+		return svgPoint{X: radius + distance*math.Cos(angle), Y: radius + distance*math.Sin(angle)}
+	}
+	center := func(pos Position) svgPoint {
+		if pos.Row == 0 {
+			return at(0, 0)
+		}
+		return at(svgCellSize*(float64(pos.Row)+0.5), (float64(pos.Col)+0.5)/float64(m.RingSizes[pos.Row]))
+	}
+
+	for ring := 1; ring < m.Rings; ring++ {
+		inner, outer := svgCellSize*float64(ring), svgCellSize*float64(ring+1)
+		for col := 0; col < m.RingSizes[ring]; col++ {
+			pos := Position{Row: ring, Col: col}
+			first := float64(col) / float64(m.RingSizes[ring])
+			last := float64(col+1) / float64(m.RingSizes[ring])
+			if !m.Linked(pos, PolarInward) {
+				w.arc(at(inner, first), at(inner, last), inner)
+			}
+			// Each wall between two cells of a ring is drawn by the cell counter-clockwise of it
+			if !m.Linked(pos, PolarClockwise) {
+				w.wall(at(inner, last), at(outer, last))
+			}
+			if ring == m.Rings-1 && pos != m.Start {
+				w.arc(at(outer, first), at(outer, last), outer)
+			}
+		}
+	}
+
+	if len(m.SolutionPath) > 0 {
+		points := make([]svgPoint, len(m.SolutionPath))
+		for i, pos := range m.SolutionPath {
+			points[i] = center(pos)
+		}
+		w.route(points, func(int) bool { return true })
+	}
+
+	w.marker(center(m.Start), svgCellSize/4, "green")
+	w.marker(center(m.Goal), svgCellSize/4, "red")
+	return w.document(2*radius, 2*radius)
+}
+
+// RenderDelta implements the DeltaRenderer interface
+func (r *SVGRenderer) RenderDelta(m *DeltaMaze) string {
+	w := &svgWriter{}
+	height := math.Sqrt(3) / 2 * svgTriangle
+	// corners returns the top-left, top-right and bottom corners of a
+	// triangle pointing down, or the bottom-left, bottom-right and top
+	// corners of one pointing up
+	corners := func(pos Position) (west, east, tip svgPoint) {
+		left := svgMargin + float64(pos.Col)*svgTriangle/2
+		top := svgMargin + float64(pos.Row)*height
+		if m.PointsUp(pos) {
+			return svgPoint{X: left, Y: top + height}, svgPoint{X: left + svgTriangle, Y: top + height}, svgPoint{X: left + svgTriangle/2, Y: top}
+		}
+		return svgPoint{X: left, Y: top}, svgPoint{X: left + svgTriangle, Y: top}, svgPoint{X: left + svgTriangle/2, Y: top + height}
+	}
+	center := func(pos Position) svgPoint {
+		west, east, tip := corners(pos)
+		return svgPoint{X: (west.X + east.X + tip.X) / 3, Y: (west.Y + east.Y + tip.Y) / 3}
+	}
+
+	for row := 0; row < m.Rows; row++ {
+		for col := 0; col < m.Cols; col++ {
+			pos := Position{Row: row, Col: col}
+			west, east, tip := corners(pos)
+			// Shared walls are drawn once, by the cell left of them or, for
+			// the horizontal sides, by the triangle pointing up
+			walls := [deltaSlots][2]svgPoint{{west, tip}, {tip, east}, {west, east}}
+			for slot, wall := range walls {
+				_, hasNeighbor := m.Neighbor(pos, slot)
+				owned := slot == DeltaEast || (slot == DeltaBase && m.PointsUp(pos))
+				if !m.Linked(pos, slot) && (owned || !hasNeighbor) {
+					w.wall(wall[0], wall[1])
+				}
+			}
+		}
+	}
+
+	if len(m.SolutionPath) > 0 {
+		points := make([]svgPoint, len(m.SolutionPath))
+		for i, pos := range m.SolutionPath {
+			points[i] = center(pos)
+		}
+		w.route(points, func(int) bool { return true })
+	}
+
+	w.marker(center(m.Start), svgTriangle/6, "green")
+	w.marker(center(m.Goal), svgTriangle/6, "red")
+	return w.document(2*svgMargin+svgTriangle*float64(m.Cols+1)/2, 2*svgMargin+height*float64(m.Rows))
+}
+
+// RenderUpsilon implements the UpsilonRenderer interface
+func (r *SVGRenderer) RenderUpsilon(m *UpsilonMaze) string {
+	w := &svgWriter{}
+	half := svgOctagon * (1 + math.Sqrt2) / 2 // Distance from the center of an octagon to its sides
+	pitch := half + svgOctagon/2              // Distance between the centers of an octagon and a square next to it
+	center := func(pos Position) svgPoint {
+		return svgPoint{X: svgMargin + half + pitch*float64(pos.Col), Y: svgMargin + half + pitch*float64(pos.Row)}
+	}
+	// The ends of the side in each slot, relative to the center of an octagon or a square
+	q := float64(svgOctagon) / 2
+	octagon := [upsilonSlots][2]svgPoint{
+		{{-q, -half}, {q, -half}},
+		{{half, -q}, {half, q}},
+		{{q, half}, {-q, half}},
+		{{-half, q}, {-half, -q}},
+		{{q, -half}, {half, -q}},
+		{{half, q}, {q, half}},
+		{{-q, half}, {-half, q}},
+		{{-half, -q}, {-q, -half}},
+	}
+	square := [UpsilonNorthEast][2]svgPoint{
+		{{-q, -q}, {q, -q}},
+		{{q, -q}, {q, q}},
+		{{q, q}, {-q, q}},
+		{{-q, q}, {-q, -q}},
+	}
+
+	for row := 0; row < m.Rows; row++ {
+		for col := 0; col < m.Cols; col++ {
+			pos := Position{Row: row, Col: col}
+			c := center(pos)
+			for slot := 0; slot < m.Degree(pos); slot++ {
+				// Shared walls are drawn once, from the cell above or left of them
+				_, hasNeighbor := m.Neighbor(pos, slot)
+				owned := slot == UpsilonEast || slot == UpsilonSouth || slot == UpsilonSouthEast || slot == UpsilonSouthWest
+				if m.Linked(pos, slot) || (hasNeighbor && !owned) {
+					continue
+				}
+				side := octagon[slot]
+				if !m.IsOctagon(pos) {
+					side = square[slot]
+				}
+				w.wall(svgPoint{X: c.X + side[0].X, Y: c.Y + side[0].Y}, svgPoint{X: c.X + side[1].X, Y: c.Y + side[1].Y})
+			}
+		}
+	}
+
+	if len(m.SolutionPath) > 0 {
+		points := make([]svgPoint, len(m.SolutionPath))
+		for i, pos := range m.SolutionPath {
+			points[i] = center(pos)
+		}
+		w.route(points, func(int) bool { return true })
+	}
+
+	w.marker(center(m.Start), svgOctagon/3, "green")
+	w.marker(center(m.Goal), svgOctagon/3, "red")
+	return w.document(2*(svgMargin+half)+pitch*float64(m.Cols-1), 2*(svgMargin+half)+pitch*float64(m.Rows-1))
+}
+
+// RenderCube implements the CubeRenderer interface, drawing the net of the
+// cube with dashed fold lines along the edges between its faces
+func (r *SVGRenderer) RenderCube(m *CubeMaze) string {
+	w := &svgWriter{}
+	net := m.Net()
+	side := 2 * m.Size // Side of a face in grid positions
+
+	// The front face is folded along its top and bottom edges to the top and
+	// bottom faces, and the row of side faces along the lines between them
+	front := cubeFrames[CubeFront].net
+	top, left := front.Row*side, front.Col*side
+	for _, row := range []int{top, top + side} {
+		w.fold(svgGridPoint(row, left), svgGridPoint(row, left+side))
+	}
+	for _, col := range []int{left, left + side, left + 2*side} {
+		w.fold(svgGridPoint(top, col), svgGridPoint(top+side, col))
+	}
+
+	w.grid(net)
+	return w.document(2*svgMargin+float64(net.Width-1)*svgCellSize/2, 2*svgMargin+float64(net.Height-1)*svgCellSize/2)
+}
+
+// RenderGraph implements the GraphRenderer interface, drawing the open
+// passages as lines between the nodes. The drawing is scaled so that the
+// median edge is as long as two square cells; every node needs a position
+// (see GraphMaze.Placed).
+func (r *SVGRenderer) RenderGraph(m *GraphMaze) string {
+	w := &svgWriter{}
+
+	lengths := make([]float64, len(m.Edges))
+	for i, edge := range m.Edges {
+		from, to := m.Nodes[edge.From], m.Nodes[edge.To]
+		lengths[i] = math.Hypot(to.X-from.X, to.Y-from.Y)
+	}
+	sort.Float64s(lengths)
+	scale := 1.0
+	if len(lengths) > 0 && lengths[len(lengths)/2] > 0 {
+		scale = 2 * svgCellSize / lengths[len(lengths)/2]
+	}
+
+	minX, minY, maxX, maxY := math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)
+	for _, node := range m.Nodes {
+		minX, minY = math.Min(minX, node.X), math.Min(minY, node.Y)
+		maxX, maxY = math.Max(maxX, node.X), math.Max(maxY, node.Y)
+	}
+	at := func(node int) svgPoint {
+		return svgPoint{X: svgMargin + (m.Nodes[node].X-minX)*scale, Y: svgMargin + (m.Nodes[node].Y-minY)*scale}
+	}
+
+	for i, edge := range m.Edges {
+		if m.Open(i) {
+			w.wall(at(edge.From), at(edge.To))
+		}
+	}
+	if len(m.SolutionPath) > 0 {
+		points := make([]svgPoint, len(m.SolutionPath))
+		for i, node := range m.SolutionPath {
+			points[i] = at(node)
+		}
+		w.route(points, func(int) bool { return true })
+	}
+
+	w.marker(at(m.Start), svgCellSize/4, "green")
+	w.marker(at(m.Goal), svgCellSize/4, "red")
+	return w.document(2*svgMargin+(maxX-minX)*scale, 2*svgMargin+(maxY-minY)*scale)
+}
@@ -0,0 +1,250 @@
+package maze
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// Topology selects which edges of the maze wrap around to the opposite edge
+type Topology int
+
+const (
+	// TopologyPlane is the usual maze with a solid border all around
+	TopologyPlane Topology = iota
+	// TopologyCylinder wraps the left and right edges around to each other
+	TopologyCylinder
+	// TopologyTorus wraps both the left and right and the top and bottom edges
+	TopologyTorus
+	// TopologyCube covers the six faces of a cube; see GenerateCube
+	TopologyCube
+)
+
+// topologyNames maps the names accepted by ParseTopology to their topologies
+var topologyNames = map[string]Topology{
+	"plane":    TopologyPlane,
+	"cylinder": TopologyCylinder,
+	"torus":    TopologyTorus,
+	"cube":     TopologyCube,
+}
+
+// ParseTopology parses a topology: "plane", "cylinder", "torus" or "cube".
+// An empty string selects TopologyPlane.
+func ParseTopology(s string) (Topology, error) {
+	value := strings.ToLower(strings.TrimSpace(s))
+	if value == "" {
+		return TopologyPlane, nil
+	}
+	if topology, ok := topologyNames[value]; ok {
+		return topology, nil
+	}
+	return TopologyPlane, fmt.Errorf("invalid topology: %q (supported: plane, cylinder, torus, cube)", s)
+}
+
+// String returns the topology in the form accepted by ParseTopology
+func (t Topology) String() string {
+	for name, topology := range topologyNames {
+		if topology == t {
+			return name
+		}
+	}
+	return ""
+}
+
+// WrapsHorizontally reports whether the left and right edges wrap around
+func (t Topology) WrapsHorizontally() bool {
+	return t == TopologyCylinder || t == TopologyTorus
+}
+
+// WrapsVertically reports whether the top and bottom edges wrap around
+func (t Topology) WrapsVertically() bool {
+	return t == TopologyTorus
+}
+
+// wraps reports whether any edge wraps around
+func (t Topology) wraps() bool {
+	return t.WrapsHorizontally() || t.WrapsVertically()
+}
+
+// cellMove is a move from one cell to a neighboring cell through the wall between them
+type cellMove struct {
+	cell Position
+	wall Position
+}
+
+// step moves from the cell at (row, col) by (dRow, dCol), two grid positions
+// along one axis, and returns the cell reached and the wall in between. A move
+// off a wrapping edge comes back on the opposite edge, crossing the border
+// position on the side it left from; a move off any other edge fails.
+func (m *Maze) step(row, col, dRow, dCol int) (cell, wall Position, ok bool) {
+	cell = Position{Row: row + dRow, Col: col + dCol}
+	wall = Position{Row: row + dRow/2, Col: col + dCol/2}
+
+	if cell.Col < 1 || cell.Col > m.Width-2 {
+		if !m.Topology.WrapsHorizontally() {
+			return cell, wall, false
+		}
+		cell.Col = wrapCoordinate(cell.Col, m.Width)
+	}
+	if cell.Row < 1 || cell.Row > m.Height-2 {
+		if !m.Topology.WrapsVertically() {
+			return cell, wall, false
+		}
+		cell.Row = wrapCoordinate(cell.Row, m.Height)
+	}
+	return cell, wall, true
+}
+
+// neighbor is step restricted to cells that are part of the maze
+func (m *Maze) neighbor(row, col, dRow, dCol int) (cell, wall Position, ok bool) {
+	cell, wall, ok = m.step(row, col, dRow, dCol)
+	return cell, wall, ok && m.cellEnabled(cell.Row, cell.Col)
+}
+
+// wrapCoordinate brings a cell coordinate that stepped past one edge of a
+// grid of the given size back in from the opposite edge
+func wrapCoordinate(value, size int) int {
+	if value < 1 {
+		return value + size - 1
+	}
+	return value - (size - 1)
+}
+
+// setWall turns the grid position into a wall or a path. The border positions
+// of a wrapping edge come in mirrored pairs that form a single wall, so both
+// are changed together.
+func (m *Maze) setWall(pos Position, wall bool) {
+	m.Grid[pos.Row][pos.Col] = wall
+	if mirror, ok := m.mirror(pos); ok {
+		m.Grid[mirror.Row][mirror.Col] = wall
+	}
+}
+
+// mirror returns the position on the opposite border that forms the same
+// wrap-around wall as pos, if pos lies on a wrapping edge between two cells
+func (m *Maze) mirror(pos Position) (Position, bool) {
+	if m.Topology.WrapsHorizontally() && pos.Row%2 == 1 && (pos.Col == 0 || pos.Col == m.Width-1) {
+		return Position{Row: pos.Row, Col: m.Width - 1 - pos.Col}, true
+	}
+	if m.Topology.WrapsVertically() && pos.Col%2 == 1 && (pos.Row == 0 || pos.Row == m.Height-1) {
+		return Position{Row: m.Height - 1 - pos.Row, Col: pos.Col}, true
+	}
+	return Position{}, false
+}
+
+// gridStep moves one grid position from pos in direction dir for pathfinding.
+// Stepping off a wrapping edge, from a border position that is open because
+// it wraps around, lands on the cell next to the opposite border.
+func (m *Maze) gridStep(pos, dir Position) (Position, bool) {
+	next := Position{Row: pos.Row + dir.Row, Col: pos.Col + dir.Col}
+	if next.Col < 0 || next.Col >= m.Width {
+		if !m.Topology.WrapsHorizontally() {
+			return next, false
+		}
+		next.Col = wrapCoordinate(next.Col, m.Width)
+	}
+	if next.Row < 0 || next.Row >= m.Height {
+		if !m.Topology.WrapsVertically() {
+			return next, false
+		}
+		next.Row = wrapCoordinate(next.Row, m.Height)
+	}
+	return next, true
+}
+
+// wrapSide returns the side of the border on which pos is an open wrap-around
+// passage, or SideNone. Renderers mark these passages.
+func (m *Maze) wrapSide(pos Position) Side {
+	if _, ok := m.mirror(pos); !ok || m.Grid[pos.Row][pos.Col] {
+		return SideNone
+	}
+	switch {
+	case pos.Row == 0:
+		return SideTop
+	case pos.Row == m.Height-1:
+		return SideBottom
+	case pos.Col == 0:
+		return SideLeft
+	default:
+		return SideRight
+	}
+}
+
+// wrapAround adds wrap-around passages to a perfect maze that was carved as
+// if its edges were solid, i.e. by an algorithm that is not a GraphAlgorithm.
+// Each wrapping wall is opened with probability 1/2, and the loop this
+// creates is broken again by closing a random passage on the route that
+// already joined its two cells, so the maze stays perfect.
+func wrapAround(maze *Maze, rng *rand.Rand) {
+	type seam struct {
+		from Position
+		cellMove
+	}
+	var seams []seam
+	addSeam := func(row, col, dRow, dCol int) {
+		if cell, wall, ok := maze.neighbor(row, col, dRow, dCol); ok && maze.cellEnabled(row, col) {
+			seams = append(seams, seam{from: Position{Row: row, Col: col}, cellMove: cellMove{cell: cell, wall: wall}})
+		}
+	}
+	if maze.Topology.WrapsHorizontally() {
+		for row := 1; row < maze.Height-1; row += 2 {
+			addSeam(row, maze.Width-2, 0, 2)
+		}
+	}
+	if maze.Topology.WrapsVertically() {
+		for col := 1; col < maze.Width-1; col += 2 {
+			addSeam(maze.Height-2, col, 2, 0)
+		}
+	}
+
+	rng.Shuffle(len(seams), func(i, j int) { seams[i], seams[j] = seams[j], seams[i] })
+	for _, seam := range seams {
+		// The mask repair may have opened the wall already, joining the two
+		// cells; the route between them would then be the wall itself
+		if rng.Intn(2) == 0 || !maze.Grid[seam.wall.Row][seam.wall.Col] {
+			continue
+		}
+		route := passageRoute(maze, seam.from, seam.cell)
+		maze.setWall(seam.wall, false)
+		if len(route) > 0 {
+			maze.setWall(route[rng.Intn(len(route))], true)
+		}
+	}
+}
+
+// passageRoute returns the walls opened along the route between two cells,
+// found with BFS over the maze's passages, or nil if they are not connected
+func passageRoute(maze *Maze, from, to Position) []Position {
+	cellCols := (maze.Width - 1) / 2
+	index := func(pos Position) int { return (pos.Row-1)/2*cellCols + (pos.Col-1)/2 }
+
+	// via[i] is the move that first reached cell i
+	via := make([]*cellMove, cellCols*((maze.Height-1)/2))
+	reached := make([]bool, len(via))
+	reached[index(from)] = true
+	queue := []Position{from}
+	for len(queue) > 0 && !reached[index(to)] {
+		current := queue[0]
+		queue = queue[1:]
+		for _, dir := range cellDirections {
+			cell, wall, ok := maze.neighbor(current.Row, current.Col, dir.Row, dir.Col)
+			if !ok || maze.Grid[wall.Row][wall.Col] || reached[index(cell)] {
+				continue
+			}
+			reached[index(cell)] = true
+			via[index(cell)] = &cellMove{cell: current, wall: wall}
+			queue = append(queue, cell)
+		}
+	}
+	if !reached[index(to)] {
+		return nil
+	}
+
+	var route []Position
+	for current := to; current != from; {
+		move := via[index(current)]
+		route = append(route, move.wall)
+		current = move.cell
+	}
+	return route
+}
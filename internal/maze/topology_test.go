@@ -0,0 +1,291 @@
+package maze
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestParseTopology(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Topology
+	}{
+		{"", TopologyPlane},
+		{"plane", TopologyPlane},
+		{"Cylinder", TopologyCylinder},
+		{" torus ", TopologyTorus},
+		{"cube", TopologyCube},
+	}
+	for _, tt := range tests {
+		topology, err := ParseTopology(tt.input)
+		if err != nil || topology != tt.expected {
+			t.Errorf("ParseTopology(%q) = %v, %v; expected %v", tt.input, topology, err, tt.expected)
+		}
+		if tt.input != "" && topology.String() != strings.ToLower(strings.TrimSpace(tt.input)) {
+			t.Errorf("Expected String() to round-trip %q, got %q", tt.input, topology.String())
+		}
+	}
+
+	if _, err := ParseTopology("sphere"); err == nil {
+		t.Error("Expected error for an unknown topology")
+	}
+}
+
+// checkWrappedMaze verifies a perfect maze whose edges may wrap around: every
+// wrap-around wall matches its mirror, all other border positions are walls,
+// every enabled cell is reachable, and n cells are joined by n-1 passages
+func checkWrappedMaze(t *testing.T, maze *Maze) int {
+	t.Helper()
+
+	seams := 0
+	border := func(pos Position) {
+		mirror, ok := maze.mirror(pos)
+		switch {
+		case !ok && !maze.Grid[pos.Row][pos.Col]:
+			t.Fatalf("Expected border position %v to be a wall", pos)
+		case ok && maze.Grid[pos.Row][pos.Col] != maze.Grid[mirror.Row][mirror.Col]:
+			t.Fatalf("Expected wrap-around wall %v to match its mirror %v", pos, mirror)
+		case ok && !maze.Grid[pos.Row][pos.Col] && (pos.Row == 0 || pos.Col == 0):
+			seams++ // Count each pair once
+		}
+	}
+	for row := 0; row < maze.Height; row++ {
+		border(Position{Row: row, Col: 0})
+		border(Position{Row: row, Col: maze.Width - 1})
+	}
+	for col := 0; col < maze.Width; col++ {
+		border(Position{Row: 0, Col: col})
+		border(Position{Row: maze.Height - 1, Col: col})
+	}
+
+	cells, passages := 0, seams
+	var first *Position
+	for row := 1; row < maze.Height-1; row++ {
+		for col := 1; col < maze.Width-1; col++ {
+			isCell := row%2 == 1 && col%2 == 1
+			if isCell && maze.cellEnabled(row, col) {
+				cells++
+				if first == nil {
+					first = &Position{Row: row, Col: col}
+				}
+				if maze.Grid[row][col] {
+					t.Fatalf("Expected cell (%d, %d) to be a path", row, col)
+				}
+			} else if !maze.Grid[row][col] {
+				passages++
+			}
+		}
+	}
+
+	distances := Distances(maze, *first)
+	for row := 0; row < maze.Height; row++ {
+		for col := 0; col < maze.Width; col++ {
+			if !maze.Grid[row][col] && distances[row][col] < 0 {
+				t.Fatalf("Open position (%d, %d) is not connected", row, col)
+			}
+		}
+	}
+
+	if passages != cells-1 {
+		t.Fatalf("Expected %d passages for a perfect maze of %d cells, got %d", cells-1, cells, passages)
+	}
+	return seams
+}
+
+func TestWrappedMazes(t *testing.T) {
+	for _, name := range GetSupportedAlgorithms() {
+		t.Run(name, func(t *testing.T) {
+			for _, topology := range []Topology{TopologyCylinder, TopologyTorus} {
+				seams := 0
+				for _, size := range [][2]int{{21, 15}, {5, 5}, {9, 31}} {
+					for _, seed := range []string{"1", "2", "3", "4"} {
+						generator, _ := NewGeneratorWithSeedAndAlgorithm(seed, name)
+						maze, err := generator.GenerateWithOptions(size[0], size[1], GenerateOptions{Topology: topology})
+						if err != nil {
+							t.Fatalf("Unexpected error: %v", err)
+						}
+						if maze.Topology != topology {
+							t.Errorf("Expected topology %v, got %v", topology, maze.Topology)
+						}
+						seams += checkWrappedMaze(t, maze)
+						if FindPath(maze) == nil {
+							t.Error("Expected a path from start to goal")
+						}
+					}
+				}
+				if seams == 0 {
+					t.Errorf("Expected some passages to wrap around on a %v", topology)
+				}
+			}
+		})
+	}
+}
+
+func TestWrappedMazeWithOptions(t *testing.T) {
+	mask, _ := ParseMaskText(strings.NewReader(heartMask))
+	for _, name := range []string{"dfs", "eller"} {
+		generator, _ := NewGeneratorWithSeedAndAlgorithm("9", name)
+		maze, err := generator.GenerateWithOptions(mask.GridWidth(), mask.GridHeight(), GenerateOptions{
+			Mask:     mask,
+			Topology: TopologyTorus,
+		})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		checkWrappedMaze(t, maze)
+	}
+
+	// Braiding and sparseness keep both halves of a wrap-around wall in step
+	generator, _ := NewGeneratorWithSeedAndAlgorithm("9", "kruskal")
+	for _, options := range []GenerateOptions{
+		{Topology: TopologyTorus, Braid: 1},
+		{Topology: TopologyCylinder, Sparse: 3},
+	} {
+		maze, err := generator.GenerateWithOptions(21, 21, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		for row := 1; row < maze.Height-1; row += 2 {
+			if maze.Grid[row][0] != maze.Grid[row][maze.Width-1] {
+				t.Errorf("Wrap-around wall on row %d does not match its mirror", row)
+			}
+		}
+		if options.Braid == 1 && len(DeadEnds(maze)) != 0 {
+			t.Error("Expected no dead ends after full braiding")
+		}
+	}
+}
+
+func TestMaskedWrappedMazes(t *testing.T) {
+	small, _ := ParseMaskText(strings.NewReader(heartMask))
+	large, err := LoadMask(filepath.Join("..", "..", "examples", "masks", "heart.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The mask repair may already open a wrap-around wall before the flat
+	// maze is wrapped, so wrapping must not cut the maze in two
+	for _, name := range GetSupportedAlgorithms() {
+		algorithm, _ := NewAlgorithm(name)
+		if _, ok := algorithm.(GraphAlgorithm); ok {
+			continue
+		}
+		t.Run(name, func(t *testing.T) {
+			for _, mask := range []*Mask{small, large} {
+				for _, topology := range []Topology{TopologyCylinder, TopologyTorus} {
+					for seed := 1; seed <= 30; seed++ {
+						generator, _ := NewGeneratorWithSeedAndAlgorithm(strconv.Itoa(seed), name)
+						maze, err := generator.GenerateWithOptions(mask.GridWidth(), mask.GridHeight(), GenerateOptions{Mask: mask, Topology: topology})
+						if err != nil {
+							t.Fatalf("Unexpected error: %v", err)
+						}
+						checkWrappedMaze(t, maze)
+						if FindPath(maze) == nil {
+							t.Fatalf("%v, seed %d: expected a path from start to goal", topology, seed)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestFindPathWrapsAround(t *testing.T) {
+	// Two cells in a row, joined only across the wrapping edge
+	maze := createTestMaze(5, 3)
+	maze.Topology = TopologyCylinder
+	maze.Grid[1][1], maze.Grid[1][3] = false, false
+	maze.setWall(Position{Row: 1, Col: 0}, false)
+	maze.StartRow, maze.StartCol = 1, 1
+	maze.GoalRow, maze.GoalCol = 1, 3
+
+	path := FindPath(maze)
+	expected := []Position{{1, 1}, {1, 0}, {1, 3}}
+	if len(path) != len(expected) {
+		t.Fatalf("Expected path %v, got %v", expected, path)
+	}
+	for i := range expected {
+		if path[i] != expected[i] {
+			t.Fatalf("Expected path %v, got %v", expected, path)
+		}
+	}
+	if distances := Distances(maze, Position{Row: 1, Col: 3}); distances[1][1] != 2 {
+		t.Errorf("Expected a distance of 2 across the wrapping edge, got %d", distances[1][1])
+	}
+
+	maze.Topology = TopologyPlane
+	if FindPath(maze) != nil {
+		t.Error("Expected no path when the edges do not wrap")
+	}
+}
+
+func TestRenderWrapMarkers(t *testing.T) {
+	maze := createTestMaze(5, 5)
+	maze.Topology = TopologyTorus
+	for _, cell := range []Position{{1, 1}, {1, 3}, {3, 1}, {3, 3}} {
+		maze.Grid[cell.Row][cell.Col] = false
+	}
+	maze.setWall(Position{Row: 1, Col: 4}, false)
+	maze.setWall(Position{Row: 4, Col: 3}, false)
+	maze.Grid[2][1] = false
+	maze.StartRow, maze.StartCol = 1, 1
+	maze.GoalRow, maze.GoalCol = 3, 3
+
+	ascii := (&ASCIIRenderer{}).Render(maze)
+	expectedASCII := "###^#\n<●# >\n# ###\n# #○#\n###v#\n"
+	if ascii != expectedASCII {
+		t.Errorf("Expected ASCII output:\n%s\ngot:\n%s", expectedASCII, ascii)
+	}
+
+	unicode := strings.Split((&UnicodeRenderer{}).Render(maze), "\n")
+	if !strings.HasPrefix(unicode[1], "←") || !strings.HasSuffix(unicode[1], "→") ||
+		!strings.Contains(unicode[0], "↑") || !strings.Contains(unicode[4], "↓") {
+		t.Errorf("Expected arrows at the wrap-around passages, got:\n%s", strings.Join(unicode, "\n"))
+	}
+
+	// Arrowheads sit on the four gaps, e.g. pointing left out of the left border
+	svg := (&SVGRenderer{}).Render(maze)
+	checkSVG(t, svg)
+	if strings.Count(svg, "<polygon") != 4 || !strings.Contains(svg, `<polygon points="5,20 15,15 15,25"`) {
+		t.Errorf("Expected arrowheads at the wrap-around passages, got:\n%s", svg)
+	}
+
+	json := (&JSONRenderer{}).Render(maze)
+	if !strings.Contains(json, `"topology": "torus"`) {
+		t.Error("Expected the topology in the JSON output")
+	}
+}
+
+func TestWrappedMazeOpenings(t *testing.T) {
+	generator := NewGeneratorWithSeed("5")
+
+	maze, err := generator.GenerateWithOptions(21, 21, GenerateOptions{
+		Topology: TopologyCylinder,
+		Entrance: SideRandom,
+		Exit:     SideBottom,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if maze.StartRow != 0 && maze.StartRow != 20 {
+		t.Errorf("Expected the random entrance on the top or bottom, got %d,%d", maze.StartRow, maze.StartCol)
+	}
+
+	tests := []struct {
+		topology Topology
+		entrance Side
+		errMsg   string
+	}{
+		{TopologyCylinder, SideLeft, "wraps around"},
+		{TopologyTorus, SideTop, "wraps around"},
+		{TopologyTorus, SideRandom, "every side"},
+	}
+	for _, tt := range tests {
+		_, err := generator.GenerateWithOptions(21, 21, GenerateOptions{Topology: tt.topology, Entrance: tt.entrance})
+		if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+			t.Errorf("%v entrance on a %v: expected error containing %q, got %v", tt.entrance, tt.topology, tt.errMsg, err)
+		}
+	}
+}
@@ -9,6 +9,8 @@ type UnicodeRenderer struct{}
 
 // Render generates a Unicode representation of the maze using box-drawing characters.
 // Uses box-drawing characters for walls, ' ' for paths, '◉' for start, '◎' for goal, and '•' for solution path.
+// Passages that wrap around to the opposite edge are marked with arrows on the border,
+// and the crossings of weave mazes with a double-lined bridge along the corridor on top.
 func (r *UnicodeRenderer) Render(m *Maze) string {
 	var sb strings.Builder
 
@@ -19,36 +21,77 @@ func (r *UnicodeRenderer) Render(m *Maze) string {
 	}
 
 	for i, row := range m.Grid {
-		for j, cell := range row {
-			currentPos := Position{Row: i, Col: j}
-
-			if i == m.StartRow && j == m.StartCol {
-				sb.WriteRune('◉') // Filled circle with dot for start
-			} else if i == m.GoalRow && j == m.GoalCol {
-				sb.WriteRune('◎') // Circle with dot for goal
-			} else if len(m.SolutionPath) > 0 && solutionSet[currentPos] {
-				sb.WriteRune('•') // Bullet for solution path
-			} else if cell {
-				// Determine appropriate box-drawing character based on connections
-				char := r.getBoxDrawingChar(m, i, j)
-				sb.WriteRune(char)
-			} else {
-				sb.WriteRune(' ') // Space for paths
-			}
+		var above, below []bool
+		if i > 0 {
+			above = m.Grid[i-1]
+		}
+		if i < len(m.Grid)-1 {
+			below = m.Grid[i+1]
+		}
+		r.writeRow(&sb, m, i, above, row, below, solutionSet)
+	}
+	return sb.String()
+}
+
+// unicodeBridges show which corridor runs on top at a crossing
+var unicodeBridges = map[Crossing]rune{CrossingHorizontal: '═', CrossingVertical: '║'}
+
+// unicodeWrapMarkers point out of the maze where a passage wraps around
+var unicodeWrapMarkers = map[Side]rune{SideTop: '↑', SideRight: '→', SideBottom: '↓', SideLeft: '←'}
+
+// RenderRow implements the RowRenderer interface.
+// The neighboring rows decide which box-drawing character each wall uses.
+func (r *UnicodeRenderer) RenderRow(m *Maze, i int, above, row, below []bool) string {
+	var sb strings.Builder
+
+	solutionSet := make(map[Position]bool)
+	for _, pos := range m.SolutionPath {
+		if pos.Row == i {
+			solutionSet[pos] = true
 		}
-		sb.WriteRune('\n')
 	}
+
+	r.writeRow(&sb, m, i, above, row, below, solutionSet)
 	return sb.String()
 }
 
+// writeRow writes a single grid row followed by a newline
+func (r *UnicodeRenderer) writeRow(sb *strings.Builder, m *Maze, i int, above, row, below []bool, solutionSet map[Position]bool) {
+	for j, cell := range row {
+		currentPos := Position{Row: i, Col: j}
+
+		if i == m.StartRow && j == m.StartCol {
+			sb.WriteRune('◉') // Filled circle with dot for start
+		} else if i == m.GoalRow && j == m.GoalCol {
+			sb.WriteRune('◎') // Circle with dot for goal
+		} else if crossing, ok := m.Crossings[currentPos]; ok {
+			sb.WriteRune(unicodeBridges[crossing]) // Also on the solution, so the crossing stays visible
+		} else if len(solutionSet) > 0 && solutionSet[currentPos] {
+			sb.WriteRune('•') // Bullet for solution path
+		} else if side := m.wrapSide(currentPos); side != SideNone {
+			sb.WriteRune(unicodeWrapMarkers[side])
+		} else if cell && m.outsideMask(i, j) {
+			sb.WriteRune(' ') // Blank outside the mask, so the silhouette shows
+		} else if cell {
+			// Determine appropriate box-drawing character based on connections
+			char := r.getBoxDrawingChar(m, i, above, row, below, j)
+			sb.WriteRune(char)
+		} else {
+			sb.WriteRune(' ') // Space for paths
+		}
+	}
+	sb.WriteRune('\n')
+}
+
 // getBoxDrawingChar determines the appropriate box-drawing character for a wall cell
-// based on its connections to adjacent wall cells
-func (r *UnicodeRenderer) getBoxDrawingChar(m *Maze, row, col int) rune {
+// based on its connections to adjacent wall cells. Walls outside the mask are
+// not drawn, so they do not count as connections.
+func (r *UnicodeRenderer) getBoxDrawingChar(m *Maze, i int, above, row, below []bool, col int) rune {
 	// Check connections in four directions
-	up := row > 0 && m.Grid[row-1][col]
-	down := row < m.Height-1 && m.Grid[row+1][col]
-	left := col > 0 && m.Grid[row][col-1]
-	right := col < m.Width-1 && m.Grid[row][col+1]
+	up := above != nil && above[col] && !m.outsideMask(i-1, col)
+	down := below != nil && below[col] && !m.outsideMask(i+1, col)
+	left := col > 0 && row[col-1] && !m.outsideMask(i, col-1)
+	right := col < len(row)-1 && row[col+1] && !m.outsideMask(i, col+1)
 
 	// Use a more efficient approach with fewer branches
 	return r.selectBoxChar(up, down, left, right)
@@ -93,3 +136,20 @@ func (r *UnicodeRenderer) selectBoxChar(up, down, left, right bool) rune {
 
 	return lookupTable[index]
 }
+
+// RenderHex implements the HexRenderer interface with diagonal box-drawing
+// walls and the same markers as Render.
+func (r *UnicodeRenderer) RenderHex(m *HexMaze) string {
+	return renderHexText(m, hexGlyphs{flat: '_', rising: '╱', falling: '╲', start: '◉', goal: '◎', solution: '•'})
+}
+
+// RenderMultiLevel implements the MultiLevelRenderer interface. Stairs are
+// marked '▲' going up, '▼' going down and '◆' going both ways.
+func (r *UnicodeRenderer) RenderMultiLevel(m *MultiLevelMaze, sideBySide bool) string {
+	return renderMultiLevelText(m, r, levelGlyphs{start: '◉', goal: '◎', up: '▲', down: '▼', both: '◆'}, sideBySide)
+}
+
+// RenderCube implements the CubeRenderer interface by drawing the net of the cube
+func (r *UnicodeRenderer) RenderCube(m *CubeMaze) string {
+	return r.Render(m.Net())
+}
@@ -0,0 +1,129 @@
+package maze
+
+import "fmt"
+
+// UpsilonMaze is a maze of octagons with small squares in the gaps between
+// them, laid out like a checkerboard: cell (row, col) is an octagon when
+// row+col is even and a square otherwise. Octagons touch their four
+// diagonal neighbors as well as the squares around them, while squares only
+// touch the four octagons around them. Cells are addressed by Position in
+// cell coordinates.
+type UpsilonMaze struct {
+	layoutMaze[Position]
+	Rows int
+	Cols int
+}
+
+// Upsilon neighbor slots. Squares only have the first four; octagons also
+// have the diagonal ones.
+const (
+	UpsilonNorth = iota
+	UpsilonEast
+	UpsilonSouth
+	UpsilonWest
+	UpsilonNorthEast
+	UpsilonSouthEast
+	UpsilonSouthWest
+	UpsilonNorthWest
+	upsilonSlots
+)
+
+// upsilonOffsets lists the neighbor in each slot as (dRow, dCol)
+var upsilonOffsets = [upsilonSlots]Position{
+	{-1, 0}, {0, 1}, {1, 0}, {0, -1}, {-1, 1}, {1, 1}, {1, -1}, {-1, -1},
+}
+
+// NewUpsilonMaze creates an upsilon maze with every wall standing, and the
+// start and goal in the top-left and bottom-right cells
+func NewUpsilonMaze(rows, cols int) *UpsilonMaze {
+	start, goal := Position{Row: 0, Col: 0}, Position{Row: rows - 1, Col: cols - 1}
+	return &UpsilonMaze{
+		layoutMaze: newLayoutMaze[Position](upsilonLayout{rows: rows, cols: cols}, start, goal),
+		Rows:       rows,
+		Cols:       cols,
+	}
+}
+
+// GenerateUpsilon creates an upsilon maze of rows x cols cells. The
+// configured algorithm must be a GraphAlgorithm.
+func (g *Generator) GenerateUpsilon(rows, cols int) (*UpsilonMaze, error) {
+	if rows < 2 || cols < 2 {
+		return nil, fmt.Errorf("upsilon maze must be at least 2x2 cells, got %dx%d", cols, rows)
+	}
+
+	maze := NewUpsilonMaze(rows, cols)
+	if err := maze.generate(g, "upsilon mazes", maze.Start); err != nil {
+		return nil, err
+	}
+	return maze, nil
+}
+
+// IsOctagon reports whether the cell at pos is an octagon rather than a square
+func (u *UpsilonMaze) IsOctagon(pos Position) bool {
+	return (pos.Row+pos.Col)%2 == 0
+}
+
+// Render draws the maze with a renderer that supports upsilon mazes, and
+// reports false if the renderer does not
+func (u *UpsilonMaze) Render(renderer Renderer) (string, bool) {
+	upsilonRenderer, ok := renderer.(UpsilonRenderer)
+	if !ok {
+		return "", false
+	}
+	return upsilonRenderer.RenderUpsilon(u), true
+}
+
+// upsilonLayout is the cellLayout of an upsilon grid, numbered in row-major order
+type upsilonLayout struct {
+	rows, cols int
+}
+
+// CellCount implements the cellLayout interface
+func (l upsilonLayout) CellCount() int {
+	return l.rows * l.cols
+}
+
+// Degree implements the cellLayout interface
+func (l upsilonLayout) Degree(cell int) int {
+	if pos := l.position(cell); (pos.Row+pos.Col)%2 != 0 {
+		return UpsilonNorthEast // Squares have no diagonal slots
+	}
+	return upsilonSlots
+}
+
+// Neighbor implements the cellLayout interface
+func (l upsilonLayout) Neighbor(cell, slot int) int {
+	next, ok := l.neighbor(l.position(cell), slot)
+	if !ok {
+		return -1
+	}
+	return l.cell(next)
+}
+
+// reverse implements the cellLayout interface: neighbors face each other
+func (l upsilonLayout) reverse(_, slot int) int {
+	if slot < UpsilonNorthEast {
+		return (slot + 2) % UpsilonNorthEast
+	}
+	return UpsilonNorthEast + (slot-UpsilonNorthEast+2)%4
+}
+
+// neighbor returns the cell next to pos in the given slot, if it is inside the grid
+func (l upsilonLayout) neighbor(pos Position, slot int) (Position, bool) {
+	if slot >= UpsilonNorthEast && (pos.Row+pos.Col)%2 != 0 {
+		return Position{}, false // Squares do not touch the squares diagonal to them
+	}
+	offset := upsilonOffsets[slot]
+	next := Position{Row: pos.Row + offset.Row, Col: pos.Col + offset.Col}
+	return next, next.Row >= 0 && next.Row < l.rows && next.Col >= 0 && next.Col < l.cols
+}
+
+// cell numbers the cells in row-major order
+func (l upsilonLayout) cell(pos Position) int {
+	return pos.Row*l.cols + pos.Col
+}
+
+// position converts a cell number back to cell coordinates
+func (l upsilonLayout) position(cell int) Position {
+	return Position{Row: cell / l.cols, Col: cell % l.cols}
+}
@@ -0,0 +1,60 @@
+package maze
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestUpsilonNeighbors(t *testing.T) {
+	maze := NewUpsilonMaze(3, 3)
+
+	octagon := Position{1, 1}
+	if !maze.IsOctagon(octagon) || maze.Degree(octagon) != 8 {
+		t.Fatalf("Expected an octagon with 8 slots at %v", octagon)
+	}
+	expected := [upsilonSlots]Position{{0, 1}, {1, 2}, {2, 1}, {1, 0}, {0, 2}, {2, 2}, {2, 0}, {0, 0}}
+	for slot, want := range expected {
+		if next, ok := maze.Neighbor(octagon, slot); !ok || next != want {
+			t.Errorf("Neighbor(%v, %d) = %v, %v; expected %v", octagon, slot, next, ok, want)
+		}
+	}
+
+	// Squares only touch the octagons beside them
+	square := Position{0, 1}
+	if maze.IsOctagon(square) || maze.Degree(square) != 4 {
+		t.Fatalf("Expected a square with 4 slots at %v", square)
+	}
+	if next, ok := maze.Neighbor(square, UpsilonSouth); !ok || next != octagon {
+		t.Errorf("Expected the octagon %v below the square, got %v", octagon, next)
+	}
+	if _, ok := maze.Neighbor(square, UpsilonSouthEast); ok {
+		t.Error("Expected a square to have no diagonal neighbors")
+	}
+}
+
+func TestRenderUpsilon(t *testing.T) {
+	// Two octagons joined diagonally, each with one of the squares beside them
+	maze := NewUpsilonMaze(2, 2)
+	maze.link(Position{0, 0}, UpsilonEast)
+	maze.link(Position{0, 0}, UpsilonSouthEast)
+	maze.link(Position{1, 1}, UpsilonWest)
+	maze.Solve()
+	if len(maze.SolutionPath) != 2 {
+		t.Fatalf("Expected the diagonal to be the solution, got %v", maze.SolutionPath)
+	}
+
+	svg := (&SVGRenderer{}).RenderUpsilon(maze)
+	walls := checkSVG(t, svg)
+	if segments := strings.Count(walls, "M"); segments != 16 {
+		t.Errorf("Expected 16 wall segments, got %d: %s", segments, walls)
+	}
+
+	var parsed UpsilonJSON
+	if err := json.Unmarshal([]byte((&JSONRenderer{}).RenderUpsilon(maze)), &parsed); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	if parsed.Shape != "upsilon" || len(parsed.Passages) != 3 || len(parsed.SolutionPath) != 2 {
+		t.Errorf("Unexpected JSON output: %+v", parsed)
+	}
+}
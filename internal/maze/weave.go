@@ -0,0 +1,173 @@
+package maze
+
+import (
+	"fmt"
+	"math"
+)
+
+// Crossing tells which corridor runs on top at a crossing cell of a weave
+// maze. All four walls of a crossing cell are open, but its corridors only
+// go straight through: the one on top passes over the other one.
+type Crossing int
+
+const (
+	// CrossingHorizontal has the corridor running left and right on top
+	CrossingHorizontal Crossing = iota + 1
+	// CrossingVertical has the corridor running up and down on top
+	CrossingVertical
+)
+
+// String returns the direction of the corridor on top
+func (c Crossing) String() string {
+	if c == CrossingVertical {
+		return "vertical"
+	}
+	return "horizontal"
+}
+
+// weaveMaxJoined caps the number of cells that crossings join into one run,
+// which keeps the degree of a weaveGraph cell small
+const weaveMaxJoined = 16
+
+// weave carves a weave maze. Crossings are laid out first: each cell away
+// from the border becomes one with the given probability, unless it is next
+// to another crossing or its corridors would close a loop. The algorithm then
+// carves the rest of the maze over a weaveGraph, in which the cells that
+// crossings join count as one.
+func (g *Generator) weave(maze *Maze, density float64) error {
+	algorithm, err := g.graphAlgorithm("weave mazes")
+	if err != nil {
+		return err
+	}
+	if density < 0 || density > 1 || math.IsNaN(density) {
+		return fmt.Errorf("weave density must be between 0.0 and 1.0, got %v", density)
+	}
+
+	grid := newGridGraph(maze)
+	sets := NewUnionFind(grid.CellCount())
+	sizes := make([]int, grid.CellCount()) // Number of cells joined into each set, kept at its root
+	for i := range sizes {
+		sizes[i] = 1
+	}
+	join := func(a, b int) {
+		joined := sizes[sets.Find(a)] + sizes[sets.Find(b)]
+		sets.Union(a, b)
+		sizes[sets.Find(a)] = joined
+	}
+
+	maze.Crossings = make(map[Position]Crossing)
+	for _, cell := range g.rand.Perm(grid.CellCount()) {
+		pos := grid.position(cell)
+		if g.rand.Float64() >= density || !canCross(maze, grid, sets, sizes, pos) {
+			continue
+		}
+
+		maze.Crossings[pos] = CrossingHorizontal
+		if g.rand.Intn(2) == 0 {
+			maze.Crossings[pos] = CrossingVertical
+		}
+		for slot := range cellDirections {
+			grid.Link(cell, slot)
+		}
+		join(grid.Neighbor(cell, 0), grid.Neighbor(cell, 2))
+		join(grid.Neighbor(cell, 1), grid.Neighbor(cell, 3))
+	}
+
+	graph := newWeaveGraph(maze, grid, sets)
+	maze.Grid[1][1] = false
+	algorithm.GenerateGraph(graph, int(graph.node[0]), g.rand)
+	return nil
+}
+
+// canCross reports whether the cell at pos can become a crossing: it needs
+// four neighbors, none of them a crossing, and its corridors must not join
+// cells that are already connected
+func canCross(maze *Maze, grid *gridGraph, sets *UnionFind, sizes []int, pos Position) bool {
+	if pos.Row == 1 || pos.Col == 1 || pos.Row == maze.Height-2 || pos.Col == maze.Width-2 {
+		return false
+	}
+
+	var roots [len(cellDirections)]int
+	for slot, dir := range cellDirections {
+		if _, ok := maze.Crossings[Position{Row: pos.Row + dir.Row, Col: pos.Col + dir.Col}]; ok {
+			return false
+		}
+		roots[slot] = sets.Find(grid.Neighbor(grid.cell(pos), slot))
+	}
+	up, right, down, left := roots[0], roots[1], roots[2], roots[3]
+	if up == down || left == right || (up == left && down == right) || (up == right && down == left) {
+		return false
+	}
+
+	joined := sizes[up] + sizes[down] + sizes[left] + sizes[right]
+	for _, same := range [][2]int{{up, left}, {up, right}, {down, left}, {down, right}} {
+		if same[0] == same[1] {
+			joined -= sizes[same[0]]
+		}
+	}
+	return joined <= weaveMaxJoined
+}
+
+// weaveGraph presents a square maze with crossings as a Graph. The cells on
+// either side of a crossing are joined through it, so each graph cell is a
+// run of grid cells, and its slots are those of its grid cells that lead to
+// neither a crossing nor another grid cell of the run.
+type weaveGraph struct {
+	grid  *gridGraph
+	node  []int32      // Graph cell of each grid cell, -1 for crossings
+	slots [][]cellSlot // Grid cell and slot behind each slot of each graph cell
+}
+
+// newWeaveGraph numbers the runs of grid cells joined by crossings
+func newWeaveGraph(maze *Maze, grid *gridGraph, sets *UnionFind) *weaveGraph {
+	g := &weaveGraph{grid: grid, node: make([]int32, grid.CellCount())}
+	roots := make(map[int]int32)
+	for cell := range g.node {
+		if _, ok := maze.Crossings[grid.position(cell)]; ok {
+			g.node[cell] = -1
+			continue
+		}
+		root := sets.Find(cell)
+		node, ok := roots[root]
+		if !ok {
+			node = int32(len(g.slots)) // #nosec G115 - cell counts fit in int32
+			roots[root] = node
+			g.slots = append(g.slots, nil)
+		}
+		g.node[cell] = node
+	}
+
+	for cell, node := range g.node {
+		if node < 0 {
+			continue
+		}
+		for slot := range cellDirections {
+			if next := grid.Neighbor(cell, slot); next >= 0 && g.node[next] >= 0 {
+				g.slots[node] = append(g.slots[node], cellSlot{cell: int32(cell), slot: int32(slot)}) // #nosec G115
+			}
+		}
+	}
+	return g
+}
+
+// CellCount implements the Graph interface
+func (g *weaveGraph) CellCount() int {
+	return len(g.slots)
+}
+
+// Degree implements the Graph interface
+func (g *weaveGraph) Degree(cell int) int {
+	return len(g.slots[cell])
+}
+
+// Neighbor implements the Graph interface
+func (g *weaveGraph) Neighbor(cell, slot int) int {
+	edge := g.slots[cell][slot]
+	return int(g.node[g.grid.Neighbor(int(edge.cell), int(edge.slot))])
+}
+
+// Link implements the Graph interface
+func (g *weaveGraph) Link(cell, slot int) {
+	edge := g.slots[cell][slot]
+	g.grid.Link(int(edge.cell), int(edge.slot))
+}
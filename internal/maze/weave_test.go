@@ -0,0 +1,247 @@
+package maze
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// checkWeaveMaze verifies a weave maze: crossings lie away from the border,
+// are never next to each other and have four open walls, every open position
+// is reachable, and the passages form a perfect maze in which each crossing
+// adds one wall to open
+func checkWeaveMaze(t *testing.T, maze *Maze) {
+	t.Helper()
+
+	for pos := range maze.Crossings {
+		if pos.Row < 3 || pos.Col < 3 || pos.Row > maze.Height-4 || pos.Col > maze.Width-4 || pos.Row%2 == 0 || pos.Col%2 == 0 {
+			t.Fatalf("Crossing %v is not a cell away from the border", pos)
+		}
+		for _, dir := range cellDirections {
+			if _, ok := maze.Crossings[Position{Row: pos.Row + dir.Row, Col: pos.Col + dir.Col}]; ok {
+				t.Fatalf("Crossing %v is next to another crossing", pos)
+			}
+			if maze.Grid[pos.Row+dir.Row/2][pos.Col+dir.Col/2] {
+				t.Fatalf("Crossing %v has a closed wall", pos)
+			}
+		}
+	}
+
+	cells, walls := 0, 0
+	for row := 1; row < maze.Height-1; row++ {
+		for col := 1; col < maze.Width-1; col++ {
+			switch {
+			case row%2 == 1 && col%2 == 1:
+				cells++
+			case row%2 != col%2 && !maze.Grid[row][col]:
+				walls++
+			}
+		}
+	}
+	if expected := cells + len(maze.Crossings) - 1; walls != expected {
+		t.Fatalf("Expected %d open walls for %d cells and %d crossings, got %d", expected, cells, len(maze.Crossings), walls)
+	}
+
+	distances := Distances(maze, Position{Row: 1, Col: 1})
+	for row := range maze.Grid {
+		for col, wall := range maze.Grid[row] {
+			if !wall && distances[row][col] < 0 {
+				t.Fatalf("Open position (%d, %d) is not connected", row, col)
+			}
+		}
+	}
+}
+
+func TestWeaveMazes(t *testing.T) {
+	for _, name := range GetSupportedAlgorithms() {
+		t.Run(name, func(t *testing.T) {
+			generator, _ := NewGeneratorWithSeedAndAlgorithm("1", name)
+			if _, ok := generator.algorithm.(GraphAlgorithm); !ok {
+				if _, err := generator.GenerateWithOptions(21, 21, GenerateOptions{Weave: 0.5}); err == nil {
+					t.Error("Expected an error for an algorithm that needs square cells")
+				}
+				return
+			}
+
+			crossings := 0
+			for _, size := range [][2]int{{21, 21}, {7, 7}, {41, 15}} {
+				for _, seed := range []string{"1", "2", "3"} {
+					generator, _ := NewGeneratorWithSeedAndAlgorithm(seed, name)
+					maze, err := generator.GenerateWithOptions(size[0], size[1], GenerateOptions{Weave: 0.8})
+					if err != nil {
+						t.Fatalf("Unexpected error: %v", err)
+					}
+					checkWeaveMaze(t, maze)
+					crossings += len(maze.Crossings)
+					if FindPath(maze) == nil {
+						t.Error("Expected a path from start to goal")
+					}
+
+					again, _ := NewGeneratorWithSeedAndAlgorithm(seed, name)
+					other, _ := again.GenerateWithOptions(size[0], size[1], GenerateOptions{Weave: 0.8})
+					if other.String() != maze.String() || len(other.Crossings) != len(maze.Crossings) {
+						t.Fatal("Expected the same seed to generate the same weave maze")
+					}
+				}
+			}
+			if crossings == 0 {
+				t.Error("Expected some crossings")
+			}
+		})
+	}
+}
+
+func TestWeaveMazeOptions(t *testing.T) {
+	generator := NewGeneratorWithSeed("4")
+	maze, err := generator.GenerateWithOptions(21, 21, GenerateOptions{Weave: 1, Braid: 1, LongestPath: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(DeadEnds(maze)) != 0 {
+		t.Error("Expected no dead ends after full braiding")
+	}
+	if _, ok := maze.Crossings[Position{Row: maze.StartRow, Col: maze.StartCol}]; ok {
+		t.Error("Expected the start not to be placed on a crossing")
+	}
+
+	// A fixed start on a crossing is rejected, naming the crossing as the cause
+	var crossing Position
+	for crossing = range maze.Crossings {
+		break
+	}
+	generator = NewGeneratorWithSeed("4")
+	_, err = generator.GenerateWithOptions(21, 21, GenerateOptions{
+		Weave: 1, Braid: 1, Start: Placement{Mode: PlacementFixed, Row: crossing.Row, Col: crossing.Col},
+	})
+	if err == nil || !strings.Contains(err.Error(), "is a weave crossing") {
+		t.Errorf("Expected an error for a start on crossing %v, got %v", crossing, err)
+	}
+
+	mask, _ := ParseMaskText(strings.NewReader(heartMask))
+	tests := []struct {
+		options GenerateOptions
+		errMsg  string
+	}{
+		{GenerateOptions{Weave: 1.5}, "between 0.0 and 1.0"},
+		{GenerateOptions{Weave: 0.5, Sparse: 1}, "cannot be combined"},
+		{GenerateOptions{Weave: 0.5, Topology: TopologyTorus}, "cannot be combined"},
+		{GenerateOptions{Weave: 0.5, Mask: mask}, "cannot be combined"},
+	}
+	for _, tt := range tests {
+		width, height := 21, 21
+		if tt.options.Mask != nil {
+			width, height = mask.GridWidth(), mask.GridHeight()
+		}
+		_, err := generator.GenerateWithOptions(width, height, tt.options)
+		if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+			t.Errorf("%+v: expected error containing %q, got %v", tt.options, tt.errMsg, err)
+		}
+	}
+}
+
+// createCrossingMaze returns a maze of 3x3 cells with a crossing in the
+// middle. The start is at the top and the goal on the right; the corridor
+// from the start passes under the horizontal one and comes back round:
+//
+//	#######
+//	### ###
+//	### ###
+//	#  -  #
+//	### # #
+//	###   #
+//	#######
+func createCrossingMaze() *Maze {
+	maze := createTestMaze(7, 7)
+	for _, pos := range []Position{
+		{1, 3}, {2, 3}, {3, 3}, {4, 3}, {5, 3}, {5, 4}, {5, 5}, {4, 5}, {3, 5}, // Under the bridge and round
+		{3, 1}, {3, 2}, {3, 4}, // Over the bridge
+	} {
+		maze.Grid[pos.Row][pos.Col] = false
+	}
+	maze.Crossings = map[Position]Crossing{{Row: 3, Col: 3}: CrossingHorizontal}
+	maze.StartRow, maze.StartCol = 1, 3
+	maze.GoalRow, maze.GoalCol = 3, 5
+	return maze
+}
+
+func TestFindPathWeave(t *testing.T) {
+	maze := createCrossingMaze()
+
+	path := FindPath(maze)
+	expected := []Position{{1, 3}, {2, 3}, {3, 3}, {4, 3}, {5, 3}, {5, 4}, {5, 5}, {4, 5}, {3, 5}}
+	if len(path) != len(expected) {
+		t.Fatalf("Expected path %v, got %v", expected, path)
+	}
+	for i := range expected {
+		if path[i] != expected[i] {
+			t.Fatalf("Expected path %v, got %v", expected, path)
+		}
+	}
+
+	distances := Distances(maze, Position{Row: 1, Col: 3})
+	if distances[3][3] != 2 || distances[3][5] != 8 || distances[3][1] != 12 {
+		t.Errorf("Expected distances 2, 8 and 12 past the crossing, got %d, %d and %d", distances[3][3], distances[3][5], distances[3][1])
+	}
+
+	// Without the crossing, the corridors meet and the goal is round the corner
+	maze.Crossings = nil
+	if path := FindPath(maze); len(path) != 5 {
+		t.Errorf("Expected a path of 5 positions through the open cell, got %v", path)
+	}
+}
+
+func TestRenderWeave(t *testing.T) {
+	maze := createCrossingMaze()
+	maze.Crossings[Position{Row: 3, Col: 3}] = CrossingVertical
+
+	ascii := (&ASCIIRenderer{}).Render(maze)
+	if lines := strings.Split(ascii, "\n"); lines[3] != "#  | ○#" {
+		t.Errorf("Expected a vertical bridge in the middle, got:\n%s", ascii)
+	}
+	unicode := (&UnicodeRenderer{}).Render(maze)
+	if !strings.Contains(unicode, "║") {
+		t.Errorf("Expected a Unicode bridge, got:\n%s", unicode)
+	}
+
+	walls := checkSVG(t, (&SVGRenderer{}).Render(maze))
+	for _, rail := range []string{"M34 30L34 50", "M46 30L46 50"} {
+		if !strings.Contains(walls, rail) {
+			t.Errorf("Expected the bridge rail %s in the SVG walls: %s", rail, walls)
+		}
+	}
+
+	var parsed JSON
+	if err := json.Unmarshal([]byte((&JSONRenderer{}).Render(maze)), &parsed); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	expected := CrossingJSON{PositionJSON: PositionJSON{Row: 3, Col: 3}, Over: "vertical"}
+	if len(parsed.Crossings) != 1 || parsed.Crossings[0] != expected {
+		t.Errorf("Expected crossings [%+v], got %+v", expected, parsed.Crossings)
+	}
+
+	// Crossings use the same lowercase keys as the other positions in the output
+	var raw struct {
+		Crossings []map[string]interface{} `json:"crossings"`
+	}
+	if err := json.Unmarshal([]byte((&JSONRenderer{}).Render(maze)), &raw); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	if len(raw.Crossings) != 1 || raw.Crossings[0]["row"] != 3.0 || raw.Crossings[0]["col"] != 3.0 {
+		t.Errorf("Expected a crossing with row and col keys, got %v", raw.Crossings)
+	}
+}
+
+func TestRenderWeaveSolution(t *testing.T) {
+	maze := createCrossingMaze()
+	maze.SolutionPath = FindPath(maze)
+
+	// The solution passes under the bridge, which stays drawn on top of it
+	expected := "#######\n###●###\n###·###\n#  - ○#\n###·#·#\n###···#\n#######\n"
+	if ascii := (&ASCIIRenderer{}).Render(maze); ascii != expected {
+		t.Errorf("Expected ASCII output:\n%s\ngot:\n%s", expected, ascii)
+	}
+	unicode := strings.Split((&UnicodeRenderer{}).Render(maze), "\n")
+	if []rune(unicode[3])[3] != '═' {
+		t.Errorf("Expected a Unicode bridge on the solution, got:\n%s", strings.Join(unicode, "\n"))
+	}
+}
@@ -7,150 +7,105 @@ type WilsonAlgorithm struct{}
 
 // Generate implements the Algorithm interface using Wilson's algorithm
 func (w *WilsonAlgorithm) Generate(maze *Maze, startRow, startCol int, rng *rand.Rand) {
-	w.generateWilson(maze, startRow, startCol, rng)
+	generateOnGrid(w, maze, startRow, startCol, rng)
 }
 
-// generateWilson implements Wilson's algorithm using loop-erased random walks
-func (w *WilsonAlgorithm) generateWilson(maze *Maze, startRow, startCol int, rng *rand.Rand) {
-	// Track which cells are part of the maze
-	inMaze := make([][]bool, maze.Height)
-	for i := range inMaze {
-		inMaze[i] = make([]bool, maze.Width)
-	}
+// GenerateGraph implements the GraphAlgorithm interface using loop-erased random walks.
+//
+// The cells not yet in the maze are kept in a Fenwick tree so that picking
+// "the k-th remaining cell" and removing a cell both take O(log n), and loops
+// are erased implicitly by remembering only the last slot each cell was left
+// through. Both choices consume random numbers exactly like the original
+// list-and-truncate implementation, so seeded mazes are unchanged, while the
+// total cost is linear in the length of the walks.
+func (w *WilsonAlgorithm) GenerateGraph(graph Graph, start int, rng *rand.Rand) {
+	cellCount := graph.CellCount()
+
+	inMaze := make([]bool, cellCount)
+	exits := make([]uint8, cellCount) // last slot each walked cell was left through
+	remaining := newFenwickTree(cellCount)
 
 	// Add the starting cell to the maze
-	maze.Grid[startRow][startCol] = false
-	inMaze[startRow][startCol] = true
-
-	// Get all cells that can be part of paths (odd coordinates)
-	cells := w.getAllPathCells(maze)
-
-	// Remove the starting cell from the list
-	cells = w.removeCellFromList(cells, startRow, startCol)
+	inMaze[start] = true
+	remaining.remove(start)
 
 	// Process each remaining cell
-	for len(cells) > 0 {
+	for remaining.count > 0 {
 		// Pick a random cell not yet in the maze
-		cellIndex := rng.Intn(len(cells))
-		currentRow, currentCol := cells[cellIndex][0], cells[cellIndex][1]
+		cell := remaining.find(rng.Intn(remaining.count))
 
-		// Skip if this cell is already in the maze
-		if inMaze[currentRow][currentCol] {
-			cells = append(cells[:cellIndex], cells[cellIndex+1:]...)
-			continue
-		}
+		// Walk until the maze is reached, remembering the last exit from every cell
+		w.randomWalk(graph, cell, inMaze, exits, rng)
 
-		// Perform loop-erased random walk
-		path := w.loopErasedRandomWalk(maze, currentRow, currentCol, inMaze, rng)
+		// Following the last exits yields the loop-erased path; add it to the maze
+		for current := cell; !inMaze[current]; {
+			graph.Link(current, int(exits[current]))
+			inMaze[current] = true
+			remaining.remove(current)
 
-		// Add the entire path to the maze
-		for i, pos := range path {
-			maze.Grid[pos[0]][pos[1]] = false
-			inMaze[pos[0]][pos[1]] = true
-
-			// Connect to previous cell in path (remove wall between them)
-			if i > 0 {
-				prev := path[i-1]
-				wallRow := (pos[0] + prev[0]) / 2
-				wallCol := (pos[1] + prev[1]) / 2
-				maze.Grid[wallRow][wallCol] = false
-			}
+			current = graph.Neighbor(current, int(exits[current]))
 		}
-
-		// Remove processed cells from the list
-		cells = w.removeProcessedCells(cells, inMaze)
 	}
 }
 
-// loopErasedRandomWalk performs a random walk with loop erasure
-func (w *WilsonAlgorithm) loopErasedRandomWalk(maze *Maze, startRow, startCol int, inMaze [][]bool, rng *rand.Rand) [][2]int {
-	// Track the current path and position in path for loop detection
-	path := make([][2]int, 0)
-	pathIndex := make(map[[2]int]int) // maps position to index in path
-
-	currentRow, currentCol := startRow, startCol
-
-	for {
-		pos := [2]int{currentRow, currentCol}
-
-		// If we've reached a cell that's already in the maze, we're done
-		if inMaze[currentRow][currentCol] {
-			path = append(path, pos)
-			break
-		}
-
-		// Check if we've created a loop
-		if index, exists := pathIndex[pos]; exists {
-			// Erase the loop by truncating the path
-			path = path[:index]
-			// Update pathIndex to remove erased positions
-			newPathIndex := make(map[[2]int]int)
-			for i, p := range path {
-				newPathIndex[p] = i
+// randomWalk performs a random walk from cell until it reaches the maze,
+// recording in exits the slot through which each cell was last left
+func (w *WilsonAlgorithm) randomWalk(graph Graph, cell int, inMaze []bool, exits []uint8, rng *rand.Rand) {
+	var slots, targets []int
+
+	for current := cell; !inMaze[current]; {
+		// Choose a random neighbor
+		slots, targets = slots[:0], targets[:0]
+		for slot := 0; slot < graph.Degree(current); slot++ {
+			if next := graph.Neighbor(current, slot); next >= 0 {
+				slots = append(slots, slot)
+				targets = append(targets, next)
 			}
-			pathIndex = newPathIndex
 		}
+		choice := rng.Intn(len(slots))
+		exits[current] = uint8(slots[choice]) // #nosec G115 - degrees fit in a byte
 
-		// Add current position to path
-		pathIndex[pos] = len(path)
-		path = append(path, pos)
-
-		// Choose a random direction
-		directions := [][2]int{{-2, 0}, {0, 2}, {2, 0}, {0, -2}} // up, right, down, left
-		validDirections := make([][2]int, 0)
-
-		for _, dir := range directions {
-			newRow := currentRow + dir[0]
-			newCol := currentCol + dir[1]
-			if w.isValidCell(maze, newRow, newCol) {
-				validDirections = append(validDirections, dir)
-			}
-		}
-
-		// Move in a random valid direction
-		if len(validDirections) > 0 {
-			dir := validDirections[rng.Intn(len(validDirections))]
-			currentRow += dir[0]
-			currentCol += dir[1]
-		}
+		current = targets[choice]
 	}
+}
 
-	return path
+// fenwickTree tracks which of n items are still present and supports
+// removing an item and finding the k-th present item in O(log n)
+type fenwickTree struct {
+	tree  []int // 1-based partial counts
+	count int   // number of items still present
 }
 
-// getAllPathCells returns all cells that can be part of paths (odd coordinates)
-func (w *WilsonAlgorithm) getAllPathCells(maze *Maze) [][2]int {
-	cells := make([][2]int, 0)
-	for row := 1; row < maze.Height; row += 2 {
-		for col := 1; col < maze.Width; col += 2 {
-			cells = append(cells, [2]int{row, col})
-		}
+// newFenwickTree creates a tree in which all n items are present
+func newFenwickTree(n int) *fenwickTree {
+	tree := make([]int, n+1)
+	for i := 1; i <= n; i++ {
+		tree[i] = i & -i // every range of length lowbit(i) is full
 	}
-	return cells
+	return &fenwickTree{tree: tree, count: n}
 }
 
-// removeCellFromList removes a specific cell from the cell list
-func (w *WilsonAlgorithm) removeCellFromList(cells [][2]int, row, col int) [][2]int {
-	for i, cell := range cells {
-		if cell[0] == row && cell[1] == col {
-			return append(cells[:i], cells[i+1:]...)
-		}
+// remove marks item i (0-based) as no longer present
+func (f *fenwickTree) remove(i int) {
+	for j := i + 1; j < len(f.tree); j += j & -j {
+		f.tree[j]--
 	}
-	return cells
+	f.count--
 }
 
-// removeProcessedCells removes cells that are now in the maze from the cell list
-func (w *WilsonAlgorithm) removeProcessedCells(cells [][2]int, inMaze [][]bool) [][2]int {
-	remaining := make([][2]int, 0)
-	for _, cell := range cells {
-		if !inMaze[cell[0]][cell[1]] {
-			remaining = append(remaining, cell)
-		}
+// find returns the index of the k-th (0-based) present item, in index order
+func (f *fenwickTree) find(k int) int {
+	step := 1
+	for step*2 < len(f.tree) {
+		step *= 2
 	}
-	return remaining
-}
 
-// isValidCell checks if a cell position is within bounds
-func (w *WilsonAlgorithm) isValidCell(maze *Maze, row, col int) bool {
-	return row > 0 && row < maze.Height-1 && col > 0 && col < maze.Width-1
+	pos := 0
+	for ; step > 0; step /= 2 {
+		if next := pos + step; next < len(f.tree) && f.tree[next] <= k {
+			pos = next
+			k -= f.tree[next]
+		}
+	}
+	return pos
 }
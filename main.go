@@ -4,6 +4,7 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/buko106/go-maze/internal/maze"
 )
@@ -11,22 +12,84 @@ import (
 func main() {
 	size := flag.Int("s", 21, "Size of the square maze (must be odd, minimum 5)")
 	flag.IntVar(size, "size", 21, "Size of the square maze (must be odd, minimum 5)")
+	width := flag.Int("width", 0, "Width of the maze (must be odd, minimum 5; defaults to --size)")
+	height := flag.Int("height", 0, "Height of the maze (must be odd, minimum 5; defaults to --size)")
 	seed := flag.String("seed", "", "Seed for reproducible maze generation (integer)")
-	algorithm := flag.String("a", "dfs", "Algorithm for maze generation (dfs, kruskal, wilson)")
-	flag.StringVar(algorithm, "algorithm", "dfs", "Algorithm for maze generation (dfs, kruskal, wilson)")
-	format := flag.String("f", "ascii", "Output format (ascii, unicode, json)")
-	flag.StringVar(format, "format", "ascii", "Output format (ascii, unicode, json)")
+	algorithm := flag.String("a", "dfs", "Algorithm for maze generation (dfs, kruskal, wilson, prim, growing-tree, eller, division, binary-tree, sidewinder, aldous-broder, hunt-and-kill)")
+	flag.StringVar(algorithm, "algorithm", "dfs", "Algorithm for maze generation (dfs, kruskal, wilson, prim, growing-tree, eller, division, binary-tree, sidewinder, aldous-broder, hunt-and-kill)")
+	bias := flag.String("bias", "", "Bias corner for binary-tree and sidewinder (NE, NW, SE, SW; default NE)")
+	strategy := flag.String("strategy", "", "Cell-selection strategy for growing-tree (newest, oldest, random, or a weighted mix like newest:75,random:25)")
+	format := flag.String("f", "ascii", "Output format (ascii, unicode, json, svg)")
+	flag.StringVar(format, "format", "ascii", "Output format (ascii, unicode, json, svg)")
+	grid := flag.String("grid", "square", "Cell shape: square, hex, delta, upsilon ((width-1)/2 x (height-1)/2 cells) or polar ((height-1)/2 rings)")
+	floors := flag.Int("floors", 1, "Number of floors stacked on top of each other and joined by stairs")
+	sideBySide := flag.Bool("side-by-side", false, "Print the floors of a multi-level maze side by side instead of one after another")
+	start := flag.String("start", "", "Start position as row,col in grid coordinates, or random, farthest, center (default 1,1)")
+	goal := flag.String("goal", "", "Goal position as row,col in grid coordinates, or random, farthest, center (default bottom-right cell)")
+	longestPath := flag.Bool("longest-path", false, "Place the start and goal at the two ends of the maze's longest path")
+	sparse := flag.Int("sparse", 0, "Number of passes that fill in dead ends, leaving solid areas between fewer corridors")
+	entrance := flag.String("entrance", "", "Carve an entrance into the border next to the start (top, right, bottom, left, random)")
+	exit := flag.String("exit", "", "Carve an exit into the border next to the goal (top, right, bottom, left, random)")
+	topology := flag.String("topology", "", "Edges that wrap around: plane (none), cylinder (left and right), torus (all four) or cube (six faces, printed as a net)")
+	maskPath := flag.String("mask", "", "Text file ('X' = disabled cell) or black-and-white PNG whose shape the maze follows; sets the maze size")
+	graphPath := flag.String("graph", "", "JSON or DOT file with an undirected graph to carve a maze over, instead of a grid")
+	var rooms, obstacles rectList
+	flag.Var(&rooms, "room", "Open room as top,left,bottom,right in grid coordinates (repeatable)")
+	flag.Var(&obstacles, "obstacle", "Solid area as top,left,bottom,right in grid coordinates (repeatable)")
+	doors := flag.Int("doors", 1, "Minimum number of doors for each --room")
+	braid := flag.Float64("braid", 0, "Fraction of dead ends to remove to create loops (0.0-1.0)")
+	weave := flag.Float64("weave", 0, "Chance that each inner cell becomes a crossing where one corridor passes under another (0.0-1.0)")
 	solution := flag.Bool("solution", false, "Display the solution path from start to goal")
+	stream := flag.Bool("stream", false, "Write the maze row by row without holding it in memory (eller, binary-tree, sidewinder; ascii, unicode)")
 	flag.Parse()
 
-	// Validate size
-	if *size < 5 {
-		fmt.Fprintf(os.Stderr, "Error: Size must be at least 5, got %d\n", *size)
-		os.Exit(1)
+	// A graph replaces the grid, so only the algorithm and output options apply
+	if *graphPath != "" {
+		flag.Visit(func(f *flag.Flag) {
+			switch f.Name {
+			case "a", "algorithm", "strategy", "bias", "seed", "f", "format", "solution", "graph":
+			case "s":
+				fmt.Fprintf(os.Stderr, "Error: -s cannot be combined with --graph\n")
+				os.Exit(1)
+			default:
+				fmt.Fprintf(os.Stderr, "Error: --%s cannot be combined with --graph\n", f.Name)
+				os.Exit(1)
+			}
+		})
 	}
-	if *size%2 == 0 {
-		fmt.Fprintf(os.Stderr, "Error: Size must be odd, got %d\n", *size)
-		os.Exit(1)
+
+	// Load the mask, which decides the maze size by itself
+	var mask *maze.Mask
+	if *maskPath != "" {
+		flag.Visit(func(f *flag.Flag) {
+			switch f.Name {
+			case "s":
+				fmt.Fprintf(os.Stderr, "Error: -s cannot be combined with --mask, which sets the maze size\n")
+				os.Exit(1)
+			case "size", "width", "height":
+				fmt.Fprintf(os.Stderr, "Error: --%s cannot be combined with --mask, which sets the maze size\n", f.Name)
+				os.Exit(1)
+			}
+		})
+
+		var err error
+		mask, err = maze.LoadMask(*maskPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: Invalid --mask: %v\n", err)
+			os.Exit(1)
+		}
+		*width, *height = mask.GridWidth(), mask.GridHeight()
+	} else {
+		// Validate size; --width and --height override it for rectangular mazes
+		validateDimension("Size", *size)
+		if *width == 0 {
+			*width = *size
+		}
+		if *height == 0 {
+			*height = *size
+		}
+		validateDimension("Width", *width)
+		validateDimension("Height", *height)
 	}
 
 	// Validate algorithm
@@ -57,13 +120,74 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Validate start and goal
+	startPlacement, err := maze.ParsePlacement(*start)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: Invalid --start: %v\n", err)
+		os.Exit(1)
+	}
+	goalPlacement, err := maze.ParsePlacement(*goal)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: Invalid --goal: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Validate braid
+	if !(*braid >= 0 && *braid <= 1) {
+		fmt.Fprintf(os.Stderr, "Error: Braid must be between 0.0 and 1.0, got %v\n", *braid)
+		os.Exit(1)
+	}
+
+	// Validate weave
+	if !(*weave >= 0 && *weave <= 1) {
+		fmt.Fprintf(os.Stderr, "Error: Weave must be between 0.0 and 1.0, got %v\n", *weave)
+		os.Exit(1)
+	}
+
+	// Validate floors
+	if *floors < 1 {
+		fmt.Fprintf(os.Stderr, "Error: Floors must be at least 1, got %d\n", *floors)
+		os.Exit(1)
+	}
+
+	// Validate sparseness
+	if *sparse < 0 {
+		fmt.Fprintf(os.Stderr, "Error: Sparse must not be negative, got %d\n", *sparse)
+		os.Exit(1)
+	}
+
+	// Validate openings
+	entranceSide, err := maze.ParseSide(*entrance)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: Invalid --entrance: %v\n", err)
+		os.Exit(1)
+	}
+	exitSide, err := maze.ParseSide(*exit)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: Invalid --exit: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Validate topology
+	topologyValue, err := maze.ParseTopology(*topology)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: Invalid --topology: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Validate doors
+	if *doors < 1 {
+		fmt.Fprintf(os.Stderr, "Error: Doors must be at least 1, got %d\n", *doors)
+		os.Exit(1)
+	}
+
 	var generator *maze.Generator
-	var err error
 
+	algorithmOptions := maze.AlgorithmOptions{Strategy: *strategy, Bias: *bias}
 	if *seed != "" {
-		generator, err = maze.NewGeneratorWithSeedAndAlgorithm(*seed, *algorithm)
+		generator, err = maze.NewGeneratorWithSeedAndAlgorithmOptions(*seed, *algorithm, algorithmOptions)
 	} else {
-		generator, err = maze.NewGeneratorWithAlgorithm(*algorithm)
+		generator, err = maze.NewGeneratorWithAlgorithmOptions(*algorithm, algorithmOptions)
 	}
 
 	if err != nil {
@@ -71,7 +195,95 @@ func main() {
 		os.Exit(1)
 	}
 
-	m := generator.Generate(*size, *size)
+	for _, room := range rooms {
+		if err := generator.AddRoom(room, *doors); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: Invalid --room: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	for _, obstacle := range obstacles {
+		if err := generator.AddObstacle(obstacle); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: Invalid --obstacle: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if *graphPath != "" {
+		renderGraphFile(generator, *graphPath, *solution, *format)
+		return
+	}
+
+	if *floors > 1 {
+		if *grid != "square" || *stream || *start != "" || *goal != "" || *longestPath || *entrance != "" || *exit != "" || *braid != 0 || *sparse != 0 ||
+			mask != nil || len(rooms) > 0 || len(obstacles) > 0 || topologyValue != maze.TopologyPlane || *weave != 0 {
+			fmt.Fprintf(os.Stderr, "Error: --grid, --stream, --start, --goal, --longest-path, --entrance, --exit, --braid, --sparse, --mask, --room, --obstacle, --topology and --weave cannot be combined with --floors\n")
+			os.Exit(1)
+		}
+		renderMultiLevel(generator, *width, *height, *floors, *sideBySide, *solution, *format)
+		return
+	}
+
+	if topologyValue == maze.TopologyCube {
+		if *grid != "square" || *stream || *start != "" || *goal != "" || *longestPath || *entrance != "" || *exit != "" || *braid != 0 || *sparse != 0 ||
+			mask != nil || len(rooms) > 0 || len(obstacles) > 0 || *weave != 0 {
+			fmt.Fprintf(os.Stderr, "Error: --grid, --stream, --start, --goal, --longest-path, --entrance, --exit, --braid, --sparse, --mask, --room, --obstacle and --weave cannot be combined with --topology cube\n")
+			os.Exit(1)
+		}
+		if *width != *height {
+			fmt.Fprintf(os.Stderr, "Error: Cube faces must be square, got %dx%d\n", *width, *height)
+			os.Exit(1)
+		}
+		renderCube(generator, (*width-1)/2, *solution, *format)
+		return
+	}
+
+	if *grid != "square" {
+		if *grid != "hex" && *grid != "polar" && *grid != "delta" && *grid != "upsilon" {
+			fmt.Fprintf(os.Stderr, "Error: Unsupported grid '%s', supported grids: [square hex polar delta upsilon]\n", *grid)
+			os.Exit(1)
+		}
+		if *stream || *start != "" || *goal != "" || *longestPath || *entrance != "" || *exit != "" || *braid != 0 || *sparse != 0 || mask != nil ||
+			len(rooms) > 0 || len(obstacles) > 0 || topologyValue != maze.TopologyPlane || *weave != 0 {
+			fmt.Fprintf(os.Stderr, "Error: --stream, --start, --goal, --longest-path, --entrance, --exit, --braid, --sparse, --mask, --room, --obstacle, --topology and --weave cannot be combined with --grid %s\n", *grid)
+			os.Exit(1)
+		}
+		renderGrid(generator, *grid, *width, *height, *solution, *format)
+		return
+	}
+
+	if *stream {
+		if *solution {
+			fmt.Fprintf(os.Stderr, "Error: --solution cannot be combined with --stream\n")
+			os.Exit(1)
+		}
+		if *start != "" || *goal != "" || *longestPath || *entrance != "" || *exit != "" || *braid != 0 || *sparse != 0 || mask != nil ||
+			len(rooms) > 0 || len(obstacles) > 0 || topologyValue != maze.TopologyPlane || *weave != 0 {
+			fmt.Fprintf(os.Stderr, "Error: --start, --goal, --longest-path, --entrance, --exit, --braid, --sparse, --mask, --room, --obstacle, --topology and --weave cannot be combined with --stream\n")
+			os.Exit(1)
+		}
+		if err := generator.Stream(os.Stdout, *width, *height, *format); err != nil {
+			fmt.Fprintf(os.Stderr, "Error streaming maze: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	m, err := generator.GenerateWithOptions(*width, *height, maze.GenerateOptions{
+		Mask:        mask,
+		Topology:    topologyValue,
+		Braid:       *braid,
+		Start:       startPlacement,
+		Goal:        goalPlacement,
+		LongestPath: *longestPath,
+		Entrance:    entranceSide,
+		Exit:        exitSide,
+		Sparse:      *sparse,
+		Weave:       *weave,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
 
 	// If solution flag is set, compute and display the solution path
 	if *solution {
@@ -90,3 +302,141 @@ func main() {
 
 	fmt.Print(renderer.Render(m))
 }
+
+// shapeMaze is a maze of hexagonal, polar, triangular or upsilon cells, or on
+// a cube, which renderShape solves and draws the same way whatever its shape
+type shapeMaze interface {
+	Solve()
+	Render(renderer maze.Renderer) (string, bool)
+}
+
+// renderGrid generates a maze with hexagonal, polar, triangular or upsilon
+// cells and writes it in the given format. Polar mazes have as many rings as a
+// square maze of the same size has rows, and the others as many cells as it.
+func renderGrid(generator *maze.Generator, grid string, width, height int, solution bool, format string) {
+	var m shapeMaze
+	var err error
+	switch grid {
+	case "hex":
+		m, err = generator.GenerateHex((height-1)/2, (width-1)/2)
+	case "polar":
+		m, err = generator.GeneratePolar((height - 1) / 2)
+	case "delta":
+		m, err = generator.GenerateDelta((height-1)/2, (width-1)/2)
+	case "upsilon":
+		m, err = generator.GenerateUpsilon((height-1)/2, (width-1)/2)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	renderShape(m, grid, solution, format)
+}
+
+// renderShape solves the maze if asked to and writes it in the given format.
+// shape names it in the error for a format that cannot draw it.
+func renderShape(m shapeMaze, shape string, solution bool, format string) {
+	if solution {
+		m.Solve()
+	}
+
+	renderer, err := maze.NewRenderer(format)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating renderer: %v\n", err)
+		os.Exit(1)
+	}
+	output, ok := m.Render(renderer)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Error: format %s does not support %s mazes\n", format, shape)
+		os.Exit(1)
+	}
+	fmt.Print(output)
+}
+
+// renderMultiLevel generates a maze of several floors of the given size and
+// writes it in the given format
+func renderMultiLevel(generator *maze.Generator, width, height, floors int, sideBySide, solution bool, format string) {
+	renderer, err := maze.NewRenderer(format)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating renderer: %v\n", err)
+		os.Exit(1)
+	}
+	levelRenderer, ok := renderer.(maze.MultiLevelRenderer)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Error: format %s does not support multi-level mazes\n", format)
+		os.Exit(1)
+	}
+
+	m, err := generator.GenerateMultiLevel(width, height, floors)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if solution {
+		m.Solve()
+	}
+	fmt.Print(levelRenderer.RenderMultiLevel(m, sideBySide))
+}
+
+// renderCube generates a maze on the surface of a cube with size x size cells
+// per face and writes it in the given format
+func renderCube(generator *maze.Generator, size int, solution bool, format string) {
+	m, err := generator.GenerateCube(size)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	renderShape(m, "cube", solution, format)
+}
+
+// renderGraphFile carves a maze over the graph in the given JSON or DOT file
+// and writes it in the given format
+func renderGraphFile(generator *maze.Generator, path string, solution bool, format string) {
+	m, err := maze.LoadGraph(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: Invalid --graph: %v\n", err)
+		os.Exit(1)
+	}
+	if format == "svg" && !m.Placed() {
+		fmt.Fprintf(os.Stderr, "Error: SVG output needs an x and y position for every node of the graph\n")
+		os.Exit(1)
+	}
+
+	if err := generator.GenerateGraphMaze(m); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	renderShape(m, "graph", solution, format)
+}
+
+// validateDimension exits with an error unless value is an odd number of at least 5
+func validateDimension(name string, value int) {
+	if value < 5 {
+		fmt.Fprintf(os.Stderr, "Error: %s must be at least 5, got %d\n", name, value)
+		os.Exit(1)
+	}
+	if value%2 == 0 {
+		fmt.Fprintf(os.Stderr, "Error: %s must be odd, got %d\n", name, value)
+		os.Exit(1)
+	}
+}
+
+// rectList collects the rectangles of a repeatable flag such as --room
+type rectList []maze.Rect
+
+func (r *rectList) String() string {
+	parts := make([]string, len(*r))
+	for i, rect := range *r {
+		parts[i] = rect.String()
+	}
+	return strings.Join(parts, " ")
+}
+
+func (r *rectList) Set(value string) error {
+	rect, err := maze.ParseRect(value)
+	if err != nil {
+		return err
+	}
+	*r = append(*r, rect)
+	return nil
+}
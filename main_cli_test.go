@@ -1,9 +1,14 @@
 package main
 
 import (
+	"encoding/json"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/buko106/go-maze/internal/maze"
 )
 
 // Test CLI with default parameters
@@ -133,3 +138,648 @@ func TestCLIShortFlags(t *testing.T) {
 		t.Errorf("Expected 9 lines for size 9, got %d", len(lines))
 	}
 }
+
+// Test CLI with --width and --height
+func TestCLIWidthHeight(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		width, height int
+	}{
+		{name: "wide and short", args: []string{"--width", "79", "--height", "21"}, width: 79, height: 21},
+		{name: "tall and narrow", args: []string{"--width", "7", "--height", "15"}, width: 7, height: 15},
+		{name: "width overrides size", args: []string{"--size", "9", "--width", "13"}, width: 13, height: 9},
+		{name: "streamed", args: []string{"-a", "sidewinder", "--stream", "--width", "31", "--height", "7"}, width: 31, height: 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := exec.Command("go", append([]string{"run", "main.go"}, tt.args...)...)
+			output, err := cmd.CombinedOutput()
+			if err != nil {
+				t.Fatalf("Command failed: %v\nOutput: %s", err, output)
+			}
+
+			lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+			if len(lines) != tt.height {
+				t.Errorf("Expected %d lines, got %d", tt.height, len(lines))
+			}
+			for i, line := range lines {
+				if n := len([]rune(line)); n != tt.width {
+					t.Errorf("Line %d: expected width %d, got %d", i, tt.width, n)
+				}
+			}
+		})
+	}
+}
+
+// Test CLI with --start and --goal
+func TestCLIStartGoal(t *testing.T) {
+	cmd := exec.Command("go", "run", "main.go", "-s", "11", "--seed", "1", "--start", "5,5", "--goal", "1,9", "-f", "json", "--solution")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("Command failed: %v\nOutput: %s", err, output)
+	}
+
+	var parsed maze.JSON
+	if err := json.Unmarshal(output, &parsed); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	if parsed.Start != (maze.Position{Row: 5, Col: 5}) || parsed.Goal != (maze.Position{Row: 1, Col: 9}) {
+		t.Errorf("Expected start (5,5) and goal (1,9), got %v and %v", parsed.Start, parsed.Goal)
+	}
+	if len(parsed.SolutionPath) == 0 || parsed.SolutionPath[0] != parsed.Start || parsed.SolutionPath[len(parsed.SolutionPath)-1] != parsed.Goal {
+		t.Error("Expected the solution path to run from the custom start to the custom goal")
+	}
+
+	// Keywords work with the text renderers
+	for _, format := range []string{"ascii", "unicode"} {
+		cmd := exec.Command("go", "run", "main.go", "-s", "11", "--seed", "1", "--start", "center", "--goal", "farthest", "-f", format)
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("Command failed: %v\nOutput: %s", err, output)
+		}
+		lines := strings.Split(string(output), "\n")
+		if runes := []rune(lines[5]); len(runes) < 6 || !strings.ContainsRune("●◉", runes[5]) {
+			t.Errorf("Expected the %s start marker at the center (5,5), got line %q", format, lines[5])
+		}
+	}
+}
+
+// Test CLI with --longest-path
+func TestCLILongestPath(t *testing.T) {
+	cmd := exec.Command("go", "run", "main.go", "-a", "kruskal", "-s", "15", "--seed", "5", "--longest-path", "-f", "json", "--solution")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("Command failed: %v\nOutput: %s", err, output)
+	}
+
+	var parsed maze.JSON
+	if err := json.Unmarshal(output, &parsed); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	if parsed.Start != (maze.Position{Row: 9, Col: 9}) || parsed.Goal != (maze.Position{Row: 13, Col: 3}) {
+		t.Errorf("Expected start (9,9) and goal (13,3), got %v and %v", parsed.Start, parsed.Goal)
+	}
+	if len(parsed.SolutionPath) != 51 {
+		t.Errorf("Expected a solution path of 51 positions, got %d", len(parsed.SolutionPath))
+	}
+}
+
+// Test CLI with --entrance and --exit
+func TestCLIOpenings(t *testing.T) {
+	cmd := exec.Command("go", "run", "main.go", "-s", "7", "--seed", "42", "--entrance", "top", "--exit", "right", "--solution")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("Command failed: %v\nOutput: %s", err, output)
+	}
+
+	expected := `#●#####
+#·#···#
+#·#·#·#
+#···#·#
+#####·#
+#    ·○
+#######
+`
+	if string(output) != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, output)
+	}
+
+	cmd = exec.Command("go", "run", "main.go", "--entrance", "up")
+	output, err = cmd.CombinedOutput()
+	if err == nil || !strings.Contains(string(output), "Invalid --entrance") {
+		t.Errorf("Expected an error for an invalid side, got: %s", output)
+	}
+}
+
+// Test CLI with --braid
+func TestCLIBraid(t *testing.T) {
+	args := []string{"run", "main.go", "-s", "15", "--seed", "3", "--braid", "0.5", "--solution"}
+	output1, err := exec.Command("go", args...).CombinedOutput()
+	if err != nil {
+		t.Fatalf("Command failed: %v\nOutput: %s", err, output1)
+	}
+	output2, _ := exec.Command("go", args...).CombinedOutput()
+	if string(output1) != string(output2) {
+		t.Error("Braided mazes with the same seed should be identical")
+	}
+
+	plain, _ := exec.Command("go", "run", "main.go", "-s", "15", "--seed", "3", "--solution").CombinedOutput()
+	if strings.Count(string(output1), "#") >= strings.Count(string(plain), "#") {
+		t.Error("Expected braiding to remove walls")
+	}
+
+	for _, value := range []string{"-0.5", "1.5"} {
+		output, err := exec.Command("go", "run", "main.go", "--braid", value).CombinedOutput()
+		if err == nil || !strings.Contains(string(output), "Braid must be between 0.0 and 1.0") {
+			t.Errorf("Expected an error for --braid %s, got: %s", value, output)
+		}
+	}
+}
+
+// Test CLI with --sparse
+func TestCLISparse(t *testing.T) {
+	plain, err := exec.Command("go", "run", "main.go", "-s", "21", "--seed", "3", "--solution").CombinedOutput()
+	if err != nil {
+		t.Fatalf("Command failed: %v\nOutput: %s", err, plain)
+	}
+	sparse, err := exec.Command("go", "run", "main.go", "-s", "21", "--seed", "3", "--solution", "--sparse", "3").CombinedOutput()
+	if err != nil {
+		t.Fatalf("Command failed: %v\nOutput: %s", err, sparse)
+	}
+
+	if strings.Count(string(sparse), "#") <= strings.Count(string(plain), "#") {
+		t.Error("Expected sparse passes to add walls")
+	}
+	if strings.Count(string(sparse), "·") != strings.Count(string(plain), "·") {
+		t.Error("Expected the solution path to be unchanged")
+	}
+
+	output, err := exec.Command("go", "run", "main.go", "--sparse", "-1").CombinedOutput()
+	if err == nil || !strings.Contains(string(output), "Sparse must not be negative") {
+		t.Errorf("Expected an error for negative --sparse, got: %s", output)
+	}
+}
+
+// Test CLI with --mask
+func TestCLIMask(t *testing.T) {
+	output, err := exec.Command("go", "run", "main.go", "--mask", "examples/masks/heart.txt", "--seed", "7", "--solution").CombinedOutput()
+	if err != nil {
+		t.Fatalf("Command failed: %v\nOutput: %s", err, output)
+	}
+
+	lines := strings.Split(strings.TrimRight(string(output), "\n"), "\n")
+	if len(lines) != 25 {
+		t.Fatalf("Expected 25 lines for the 15x12 heart mask, got %d", len(lines))
+	}
+	for _, line := range lines {
+		if len([]rune(line)) != 31 {
+			t.Errorf("Expected lines of width 31, got %q", line)
+		}
+	}
+	if !strings.HasPrefix(lines[0], "   ") || !strings.HasPrefix(lines[len(lines)-1], "   ") {
+		t.Error("Expected the corners outside the heart to be blank")
+	}
+	if !strings.Contains(string(output), "·") {
+		t.Error("Expected a solution path inside the mask")
+	}
+
+	disconnected := filepath.Join(t.TempDir(), "split.txt")
+	if err := os.WriteFile(disconnected, []byte("..X..\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		args   []string
+		errMsg string
+	}{
+		{[]string{"--mask", "examples/masks/heart.txt", "-s", "21"}, "-s cannot be combined with --mask"},
+		{[]string{"--mask", "examples/masks/heart.txt", "--width", "21"}, "--width cannot be combined with --mask"},
+		{[]string{"--mask", "examples/masks/missing.txt"}, "Invalid --mask"},
+		{[]string{"--mask", disconnected}, "single connected region"},
+	}
+	for _, tt := range tests {
+		output, err := exec.Command("go", append([]string{"run", "main.go"}, tt.args...)...).CombinedOutput()
+		if err == nil || !strings.Contains(string(output), tt.errMsg) {
+			t.Errorf("Args %v: expected error containing %q, got: %s", tt.args, tt.errMsg, output)
+		}
+	}
+}
+
+// Test CLI with --room, --obstacle and --doors
+func TestCLIRoomsAndObstacles(t *testing.T) {
+	output, err := exec.Command("go", "run", "main.go", "-s", "21", "--seed", "4", "-f", "json",
+		"--room", "5,5,9,11", "--room", "13,13,17,17", "--doors", "2", "--obstacle", "13,1,19,7").CombinedOutput()
+	if err != nil {
+		t.Fatalf("Command failed: %v\nOutput: %s", err, output)
+	}
+
+	var result maze.JSON
+	if err := json.Unmarshal(output, &result); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	if len(result.Rooms) != 2 {
+		t.Fatalf("Expected 2 rooms, got %+v", result.Rooms)
+	}
+	for _, room := range result.Rooms {
+		if len(room.Doors) < 2 {
+			t.Errorf("Expected room %s to have at least 2 doors, got %v", room.Rect, room.Doors)
+		}
+	}
+	for row := 13; row <= 19; row++ {
+		for col := 1; col <= 7; col++ {
+			if !result.Grid[row][col] {
+				t.Errorf("Expected the obstacle to be solid at %d,%d", row, col)
+			}
+		}
+	}
+
+	tests := []struct {
+		args   []string
+		errMsg string
+	}{
+		{[]string{"--room", "1,1"}, "invalid rectangle"},
+		{[]string{"--room", "2,2,4,4"}, "Invalid --room"},
+		{[]string{"--obstacle", "1,1,3,4"}, "Invalid --obstacle"},
+		{[]string{"--room", "1,1,3,3", "--doors", "0"}, "Doors must be at least 1"},
+		{[]string{"-s", "9", "--room", "1,1,9,3"}, "does not fit"},
+		{[]string{"-s", "9", "--obstacle", "5,1,5,7"}, "connected"},
+	}
+	for _, tt := range tests {
+		output, err := exec.Command("go", append([]string{"run", "main.go"}, tt.args...)...).CombinedOutput()
+		if err == nil || !strings.Contains(string(output), tt.errMsg) {
+			t.Errorf("Args %v: expected error containing %q, got: %s", tt.args, tt.errMsg, output)
+		}
+	}
+}
+
+// Test CLI with --topology
+func TestCLITopology(t *testing.T) {
+	output, err := exec.Command("go", "run", "main.go", "-s", "15", "--seed", "8", "--topology", "torus", "--solution").CombinedOutput()
+	if err != nil {
+		t.Fatalf("Command failed: %v\nOutput: %s", err, output)
+	}
+	lines := strings.Split(strings.TrimRight(string(output), "\n"), "\n")
+	if len(lines) != 15 {
+		t.Fatalf("Expected 15 lines, got %d", len(lines))
+	}
+	if !strings.ContainsAny(string(output), "<>^v") {
+		t.Errorf("Expected wrap-around markers on the border, got:\n%s", output)
+	}
+
+	output, err = exec.Command("go", "run", "main.go", "-s", "15", "--seed", "8", "--topology", "cylinder", "-f", "json").CombinedOutput()
+	if err != nil {
+		t.Fatalf("Command failed: %v\nOutput: %s", err, output)
+	}
+	var result maze.JSON
+	if err := json.Unmarshal(output, &result); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	if result.Topology != "cylinder" {
+		t.Errorf("Expected topology cylinder, got %q", result.Topology)
+	}
+
+	tests := []struct {
+		args   []string
+		errMsg string
+	}{
+		{[]string{"--topology", "sphere"}, "Invalid --topology"},
+		{[]string{"--topology", "cylinder", "--entrance", "left"}, "wraps around"},
+	}
+	for _, tt := range tests {
+		output, err := exec.Command("go", append([]string{"run", "main.go"}, tt.args...)...).CombinedOutput()
+		if err == nil || !strings.Contains(string(output), tt.errMsg) {
+			t.Errorf("Args %v: expected error containing %q, got: %s", tt.args, tt.errMsg, output)
+		}
+	}
+}
+
+// Test CLI with invalid --start and --goal
+func TestCLIStartGoalErrors(t *testing.T) {
+	tests := []struct {
+		args   []string
+		errMsg string
+	}{
+		{[]string{"--start", "nowhere"}, "Invalid --start"},
+		{[]string{"--goal", "1;1"}, "Invalid --goal"},
+		{[]string{"-s", "9", "--start", "2,2"}, "start 2,2 is not a cell position"},
+		{[]string{"-s", "9", "--goal", "9,9"}, "goal 9,9 is outside the maze"},
+		{[]string{"--start", "farthest", "--goal", "farthest"}, "cannot both be placed farthest"},
+		{[]string{"--longest-path", "--goal", "center"}, "cannot be combined with a start or goal"},
+	}
+
+	for _, tt := range tests {
+		cmd := exec.Command("go", append([]string{"run", "main.go"}, tt.args...)...)
+		output, err := cmd.CombinedOutput()
+		if err == nil {
+			t.Errorf("Expected command %v to fail", tt.args)
+		}
+		if !strings.Contains(string(output), tt.errMsg) {
+			t.Errorf("Expected error message '%s' but got '%s'", tt.errMsg, string(output))
+		}
+	}
+}
+
+// Test CLI streaming output matches regular output
+func TestCLIStream(t *testing.T) {
+	for _, format := range []string{"ascii", "unicode"} {
+		cmd1 := exec.Command("go", "run", "main.go", "-a", "eller", "--seed", "7", "--size", "15", "-f", format)
+		output1, err1 := cmd1.CombinedOutput()
+		if err1 != nil {
+			t.Fatalf("Command failed: %v\nOutput: %s", err1, output1)
+		}
+
+		cmd2 := exec.Command("go", "run", "main.go", "-a", "eller", "--seed", "7", "--size", "15", "-f", format, "--stream")
+		output2, err2 := cmd2.CombinedOutput()
+		if err2 != nil {
+			t.Fatalf("Command failed: %v\nOutput: %s", err2, output2)
+		}
+
+		if string(output1) != string(output2) {
+			t.Errorf("Streamed %s output differs from regular output.\nRegular:\n%s\nStreamed:\n%s", format, output1, output2)
+		}
+	}
+}
+
+// Test CLI streaming rejects unsupported combinations
+func TestCLIStreamErrors(t *testing.T) {
+	tests := []struct {
+		args   []string
+		errMsg string
+	}{
+		{[]string{"-a", "dfs", "--stream"}, "does not support row-by-row streaming"},
+		{[]string{"-a", "eller", "--stream", "-f", "json"}, "does not support row-by-row streaming"},
+		{[]string{"-a", "eller", "--stream", "--solution"}, "--solution cannot be combined with --stream"},
+		{[]string{"-a", "eller", "--stream", "--start", "center"}, "--start, --goal, --longest-path, --entrance, --exit, --braid, --sparse, --mask, --room, --obstacle, --topology and --weave cannot be combined with --stream"},
+	}
+
+	for _, tt := range tests {
+		cmd := exec.Command("go", append([]string{"run", "main.go"}, tt.args...)...)
+		output, err := cmd.CombinedOutput()
+		if err == nil {
+			t.Errorf("Expected command %v to fail", tt.args)
+		}
+		if !strings.Contains(string(output), tt.errMsg) {
+			t.Errorf("Expected error message '%s' but got '%s'", tt.errMsg, string(output))
+		}
+	}
+}
+
+// Test CLI with hexagonal cells
+func TestCLIHexGrid(t *testing.T) {
+	output, err := exec.Command("go", "run", "main.go", "--grid", "hex", "-s", "9", "--seed", "3", "--solution").CombinedOutput()
+	if err != nil {
+		t.Fatalf("Command failed: %v\nOutput: %s", err, output)
+	}
+	// 4x4 cells take two lines each, plus the top edge and the shifted last column
+	lines := strings.Split(strings.TrimRight(string(output), "\n"), "\n")
+	if len(lines) != 10 {
+		t.Errorf("Expected 10 lines, got %d:\n%s", len(lines), output)
+	}
+	for _, marker := range []string{"/", "\\", "●", "○", "·"} {
+		if !strings.Contains(string(output), marker) {
+			t.Errorf("Expected %q in the hexagonal maze, got:\n%s", marker, output)
+		}
+	}
+
+	output, err = exec.Command("go", "run", "main.go", "--grid", "hex", "-a", "wilson", "-f", "svg").CombinedOutput()
+	if err != nil {
+		t.Fatalf("Command failed: %v\nOutput: %s", err, output)
+	}
+	if !strings.HasPrefix(string(output), "<svg ") {
+		t.Errorf("Expected SVG output, got:\n%s", output)
+	}
+
+	tests := []struct {
+		args   []string
+		errMsg string
+	}{
+		{[]string{"--grid", "triangular"}, "Unsupported grid"},
+		{[]string{"--grid", "hex", "-a", "eller"}, "does not support hexagonal mazes"},
+		{[]string{"--grid", "hex", "--braid", "0.5"}, "cannot be combined with --grid hex"},
+	}
+	for _, tt := range tests {
+		output, err := exec.Command("go", append([]string{"run", "main.go"}, tt.args...)...).CombinedOutput()
+		if err == nil || !strings.Contains(string(output), tt.errMsg) {
+			t.Errorf("Args %v: expected error containing %q, got: %s", tt.args, tt.errMsg, output)
+		}
+	}
+}
+
+// Test CLI with circular mazes
+func TestCLIPolarGrid(t *testing.T) {
+	output, err := exec.Command("go", "run", "main.go", "--grid", "polar", "-s", "11", "--seed", "3", "--solution", "-f", "svg").CombinedOutput()
+	if err != nil {
+		t.Fatalf("Command failed: %v\nOutput: %s", err, output)
+	}
+	// Five rings of 20 pixels around the centre, plus the margin
+	if !strings.HasPrefix(string(output), `<svg xmlns="http://www.w3.org/2000/svg" width="220" height="220"`) {
+		t.Errorf("Expected a 220x220 SVG drawing, got:\n%s", output)
+	}
+	if !strings.Contains(string(output), "#1e88e5") {
+		t.Error("Expected the solution route in the drawing")
+	}
+
+	output, err = exec.Command("go", "run", "main.go", "--grid", "polar", "-s", "11", "-f", "json").CombinedOutput()
+	if err != nil {
+		t.Fatalf("Command failed: %v\nOutput: %s", err, output)
+	}
+	var result maze.PolarJSON
+	if err := json.Unmarshal(output, &result); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	if result.Rings != 5 || result.Goal != (maze.Position{Row: 0, Col: 0}) || result.Start.Row != 4 {
+		t.Errorf("Expected 5 rings from the rim to the centre, got %+v", result)
+	}
+
+	output, err = exec.Command("go", "run", "main.go", "--grid", "polar").CombinedOutput()
+	if err == nil || !strings.Contains(string(output), "format ascii does not support polar mazes") {
+		t.Errorf("Expected an error for ASCII output, got: %s", output)
+	}
+}
+
+// Test CLI with triangular and upsilon mazes
+func TestCLIDeltaAndUpsilonGrids(t *testing.T) {
+	for _, grid := range []string{"delta", "upsilon"} {
+		output, err := exec.Command("go", "run", "main.go", "--grid", grid, "-s", "11", "--seed", "3", "--solution", "-f", "json").CombinedOutput()
+		if err != nil {
+			t.Fatalf("Command failed: %v\nOutput: %s", err, output)
+		}
+		var result maze.DeltaJSON
+		if err := json.Unmarshal(output, &result); err != nil {
+			t.Fatalf("Failed to parse JSON output: %v", err)
+		}
+		// A perfect maze of 5x5 cells has 24 passages
+		if result.Shape != grid || result.Rows != 5 || result.Cols != 5 || len(result.Passages) != 24 || len(result.SolutionPath) == 0 {
+			t.Errorf("Unexpected %s maze: %+v", grid, result)
+		}
+
+		output, err = exec.Command("go", "run", "main.go", "--grid", grid, "-f", "svg").CombinedOutput()
+		if err != nil || !strings.HasPrefix(string(output), "<svg ") {
+			t.Errorf("Expected SVG output for --grid %s, got: %s", grid, output)
+		}
+
+		output, err = exec.Command("go", "run", "main.go", "--grid", grid, "-a", "eller").CombinedOutput()
+		if err == nil || !strings.Contains(string(output), "does not support") {
+			t.Errorf("Expected an error for Eller's algorithm on --grid %s, got: %s", grid, output)
+		}
+	}
+}
+
+// Test CLI with weave mazes
+func TestCLIWeave(t *testing.T) {
+	output, err := exec.Command("go", "run", "main.go", "--weave", "0.8", "-s", "21", "--seed", "2", "-f", "json", "--solution").CombinedOutput()
+	if err != nil {
+		t.Fatalf("Command failed: %v\nOutput: %s", err, output)
+	}
+	var result maze.JSON
+	if err := json.Unmarshal(output, &result); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	if len(result.Crossings) == 0 || len(result.SolutionPath) == 0 {
+		t.Errorf("Expected crossings and a solution, got %d crossings and %d solution steps", len(result.Crossings), len(result.SolutionPath))
+	}
+
+	output, err = exec.Command("go", "run", "main.go", "--weave", "0.8", "-s", "21", "--seed", "2", "-f", "unicode").CombinedOutput()
+	if err != nil {
+		t.Fatalf("Command failed: %v\nOutput: %s", err, output)
+	}
+	if !strings.ContainsAny(string(output), "═║") {
+		t.Errorf("Expected bridges in the Unicode output, got:\n%s", output)
+	}
+
+	tests := []struct {
+		args   []string
+		errMsg string
+	}{
+		{[]string{"--weave", "2"}, "Weave must be between 0.0 and 1.0"},
+		{[]string{"--weave", "0.5", "-a", "eller"}, "does not support weave mazes"},
+		{[]string{"--weave", "1", "-s", "15", "--seed", "3", "--start", "3,5"}, "start 3,5 is a weave crossing"},
+		{[]string{"--weave", "0.5", "--sparse", "1"}, "cannot be combined"},
+		{[]string{"--weave", "0.5", "--stream", "-a", "eller"}, "cannot be combined with --stream"},
+	}
+	for _, tt := range tests {
+		output, err := exec.Command("go", append([]string{"run", "main.go"}, tt.args...)...).CombinedOutput()
+		if err == nil || !strings.Contains(string(output), tt.errMsg) {
+			t.Errorf("%v: expected error containing %q, got: %s", tt.args, tt.errMsg, output)
+		}
+	}
+}
+
+// Test CLI with multi-level mazes
+func TestCLIMultiLevel(t *testing.T) {
+	output, err := exec.Command("go", "run", "main.go", "--floors", "3", "-s", "11", "--seed", "2", "-f", "json", "--solution").CombinedOutput()
+	if err != nil {
+		t.Fatalf("Command failed: %v\nOutput: %s", err, output)
+	}
+	var result maze.MultiLevelJSON
+	if err := json.Unmarshal(output, &result); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	if result.Depth != 3 || len(result.Floors) != 3 || len(result.Stairs) < 2 || len(result.SolutionPath) == 0 {
+		t.Errorf("Unexpected multi-level maze: %+v", result)
+	}
+
+	output, err = exec.Command("go", "run", "main.go", "--floors", "2", "-s", "11", "--seed", "2", "--side-by-side").CombinedOutput()
+	if err != nil {
+		t.Fatalf("Command failed: %v\nOutput: %s", err, output)
+	}
+	if lines := strings.Split(string(output), "\n"); !strings.HasPrefix(lines[0], "Floor 1") || !strings.Contains(lines[0], "Floor 2") || !strings.Contains(string(output), "<") {
+		t.Errorf("Expected two floors side by side with stairs, got:\n%s", output)
+	}
+
+	tests := []struct {
+		args   []string
+		errMsg string
+	}{
+		{[]string{"--floors", "0"}, "Floors must be at least 1"},
+		{[]string{"--floors", "2", "-a", "eller"}, "does not support multi-level mazes"},
+		{[]string{"--floors", "2", "-f", "svg"}, "does not support multi-level mazes"},
+		{[]string{"--floors", "2", "--braid", "0.5"}, "cannot be combined with --floors"},
+		{[]string{"--floors", "2", "--grid", "hex"}, "cannot be combined with --floors"},
+	}
+	for _, tt := range tests {
+		output, err := exec.Command("go", append([]string{"run", "main.go"}, tt.args...)...).CombinedOutput()
+		if err == nil || !strings.Contains(string(output), tt.errMsg) {
+			t.Errorf("%v: expected error containing %q, got: %s", tt.args, tt.errMsg, output)
+		}
+	}
+}
+
+// Test CLI with cube mazes
+func TestCLICube(t *testing.T) {
+	output, err := exec.Command("go", "run", "main.go", "--topology", "cube", "-s", "7", "--seed", "2", "-f", "json", "--solution").CombinedOutput()
+	if err != nil {
+		t.Fatalf("Command failed: %v\nOutput: %s", err, output)
+	}
+	var result maze.CubeJSON
+	if err := json.Unmarshal(output, &result); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	// A perfect maze of 6 faces of 3x3 cells has 53 passages
+	if result.Shape != "cube" || result.Size != 3 || len(result.Passages) != 53 || len(result.SolutionPath) == 0 {
+		t.Errorf("Unexpected cube maze: %+v", result)
+	}
+
+	output, err = exec.Command("go", "run", "main.go", "--topology", "cube", "-s", "7", "--seed", "2").CombinedOutput()
+	if err != nil {
+		t.Fatalf("Command failed: %v\nOutput: %s", err, output)
+	}
+	// The net is four faces wide and three faces high
+	if lines := strings.Split(strings.TrimSuffix(string(output), "\n"), "\n"); len(lines) != 19 || len(lines[9]) != 25 {
+		t.Errorf("Expected a 25x19 net, got:\n%s", output)
+	}
+
+	output, err = exec.Command("go", "run", "main.go", "--topology", "cube", "-f", "svg").CombinedOutput()
+	if err != nil || !strings.Contains(string(output), "stroke-dasharray") {
+		t.Errorf("Expected SVG output with fold lines, got: %s", output)
+	}
+
+	tests := []struct {
+		args   []string
+		errMsg string
+	}{
+		{[]string{"--topology", "cube", "-a", "eller"}, "does not support cube mazes"},
+		{[]string{"--topology", "cube", "--width", "7", "--height", "9"}, "Cube faces must be square"},
+		{[]string{"--topology", "cube", "--braid", "0.5"}, "cannot be combined with --topology cube"},
+	}
+	for _, tt := range tests {
+		output, err := exec.Command("go", append([]string{"run", "main.go"}, tt.args...)...).CombinedOutput()
+		if err == nil || !strings.Contains(string(output), tt.errMsg) {
+			t.Errorf("%v: expected error containing %q, got: %s", tt.args, tt.errMsg, output)
+		}
+	}
+}
+
+func TestCLIGraph(t *testing.T) {
+	output, err := exec.Command("go", "run", "main.go", "--graph", "examples/graphs/petersen.json", "-a", "wilson", "--seed", "4", "-f", "json", "--solution").CombinedOutput()
+	if err != nil {
+		t.Fatalf("Command failed: %v\nOutput: %s", err, output)
+	}
+	var result maze.GraphJSON
+	if err := json.Unmarshal(output, &result); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	// A spanning tree of the 10 nodes has 9 passages
+	if result.Shape != "graph" || len(result.Nodes) != 10 || len(result.Passages) != 9 ||
+		result.Start != "o0" || result.Goal != "i4" || len(result.SolutionPath) < 2 {
+		t.Errorf("Unexpected graph maze: %+v", result)
+	}
+
+	output, err = exec.Command("go", "run", "main.go", "--graph", "examples/graphs/subway.dot", "-a", "kruskal", "--solution").CombinedOutput()
+	if err != nil {
+		t.Fatalf("Command failed: %v\nOutput: %s", err, output)
+	}
+	if !strings.HasPrefix(string(output), "graph maze {") || strings.Count(string(output), " -- ") != 11 ||
+		!strings.Contains(string(output), `"Stadium" -- "Library";`) {
+		t.Errorf("Expected DOT output with the 11 fastest links, got:\n%s", output)
+	}
+
+	output, err = exec.Command("go", "run", "main.go", "--graph", "examples/graphs/subway.dot", "-f", "svg", "--solution").CombinedOutput()
+	if err != nil || !strings.HasPrefix(string(output), "<svg") {
+		t.Errorf("Expected SVG output, got: %s", output)
+	}
+
+	unplaced := filepath.Join(t.TempDir(), "unplaced.dot")
+	if err := os.WriteFile(unplaced, []byte("graph { a -- b -- c }\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		args   []string
+		errMsg string
+	}{
+		{[]string{"--graph", unplaced, "-f", "svg"}, "SVG output needs an x and y position"},
+		{[]string{"--graph", unplaced, "-f", "unicode"}, "format unicode does not support graph mazes"},
+		{[]string{"--graph", unplaced, "-a", "eller"}, "does not support arbitrary graphs"},
+		{[]string{"--graph", unplaced, "-s", "7"}, "-s cannot be combined with --graph"},
+		{[]string{"--graph", unplaced, "--braid", "0.5"}, "--braid cannot be combined with --graph"},
+		{[]string{"--graph", filepath.Join(t.TempDir(), "missing.dot")}, "Invalid --graph"},
+	}
+	for _, tt := range tests {
+		output, err := exec.Command("go", append([]string{"run", "main.go"}, tt.args...)...).CombinedOutput()
+		if err == nil || !strings.Contains(string(output), tt.errMsg) {
+			t.Errorf("%v: expected error containing %q, got: %s", tt.args, tt.errMsg, output)
+		}
+	}
+}
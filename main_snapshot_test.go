@@ -59,6 +59,26 @@ func TestFormatFlagSnapshot(t *testing.T) {
 ├───┘ │
 │    ◎│
 └─────┘
+`,
+		},
+		{
+			name: "wilson ascii snapshot",
+			args: []string{"-s", "15", "--seed", "456", "-a", "wilson"},
+			expectedOutput: `###############
+#●  #       # #
+# # # # ### # #
+# #   # #   # #
+# ##### # ### #
+#   #   #   # #
+# ### ### ### #
+#   # # #     #
+# ### # ### # #
+# #   #     # #
+# ### #########
+# #     #     #
+### ### ##### #
+#     #      ○#
+###############
 `,
 		},
 	}
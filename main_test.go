@@ -47,6 +47,23 @@ func TestSizeValidation(t *testing.T) {
 			wantErr: true,
 			errMsg:  "Size must be odd",
 		},
+		{
+			name:    "rectangular maze",
+			args:    []string{"--width", "79", "--height", "21"},
+			wantErr: false,
+		},
+		{
+			name:    "width too small",
+			args:    []string{"--width", "3"},
+			wantErr: true,
+			errMsg:  "Width must be at least 5",
+		},
+		{
+			name:    "even height",
+			args:    []string{"--height", "10"},
+			wantErr: true,
+			errMsg:  "Height must be odd",
+		},
 	}
 
 	for _, tt := range tests {
@@ -191,6 +208,60 @@ func TestAlgorithmFlag(t *testing.T) {
 			args:    []string{"-s", "9", "--algorithm", "kruskal"},
 			wantErr: false,
 		},
+		{
+			name:    "prim algorithm short flag",
+			args:    []string{"-s", "9", "-a", "prim"},
+			wantErr: false,
+		},
+		{
+			name:    "growing-tree algorithm with weighted strategy",
+			args:    []string{"-s", "9", "-a", "growing-tree", "--strategy", "newest:75,random:25"},
+			wantErr: false,
+		},
+		{
+			name:    "growing-tree algorithm with invalid strategy",
+			args:    []string{"-s", "9", "-a", "growing-tree", "--strategy", "sideways"},
+			wantErr: true,
+			errMsg:  "unknown growing tree strategy",
+		},
+		{
+			name:    "strategy with non growing-tree algorithm",
+			args:    []string{"-s", "9", "-a", "dfs", "--strategy", "random"},
+			wantErr: true,
+			errMsg:  "does not support a cell-selection strategy",
+		},
+		{
+			name:    "binary-tree algorithm with bias",
+			args:    []string{"-s", "9", "-a", "binary-tree", "--bias", "SW"},
+			wantErr: false,
+		},
+		{
+			name:    "sidewinder algorithm with default bias",
+			args:    []string{"-s", "9", "-a", "sidewinder"},
+			wantErr: false,
+		},
+		{
+			name:    "sidewinder algorithm with invalid bias",
+			args:    []string{"-s", "9", "-a", "sidewinder", "--bias", "up"},
+			wantErr: true,
+			errMsg:  "invalid bias",
+		},
+		{
+			name:    "bias with unbiased algorithm",
+			args:    []string{"-s", "9", "-a", "kruskal", "--bias", "NE"},
+			wantErr: true,
+			errMsg:  "does not support a bias",
+		},
+		{
+			name:    "aldous-broder algorithm",
+			args:    []string{"-s", "9", "-a", "aldous-broder"},
+			wantErr: false,
+		},
+		{
+			name:    "hunt-and-kill algorithm",
+			args:    []string{"-s", "9", "-a", "hunt-and-kill"},
+			wantErr: false,
+		},
 		{
 			name:    "invalid algorithm",
 			args:    []string{"-s", "9", "-a", "invalid"},
@@ -199,9 +270,9 @@ func TestAlgorithmFlag(t *testing.T) {
 		},
 		{
 			name:    "invalid algorithm long flag",
-			args:    []string{"-s", "9", "--algorithm", "prim"},
+			args:    []string{"-s", "9", "--algorithm", "bogus"},
 			wantErr: true,
-			errMsg:  "Unsupported algorithm 'prim'",
+			errMsg:  "Unsupported algorithm 'bogus'",
 		},
 		{
 			name:    "algorithm with seed",